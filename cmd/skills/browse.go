@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/portertech/skills-mcp-server/internal/browse"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"golang.org/x/term"
+)
+
+// errNotTerminal is returned when "skills browse" is run without a terminal.
+var errNotTerminal = errors.New("browse needs an interactive terminal; use --list instead")
+
+// runBrowse implements "skills browse", an interactive browser for the
+// skills root.
+func runBrowse(args []string) int {
+	flags := flag.NewFlagSet("browse", flag.ContinueOnError)
+	var (
+		locale   string
+		editor   string
+		verbose  bool
+		regFlags registryFlags
+	)
+	flags.StringVar(&locale, "locale", "", "Preview localized SKILL.<lang>.md variants for this language tag where available")
+	flags.StringVar(&editor, "editor", "", "Command that opens a skill for editing (default: $VISUAL, else $EDITOR, else vi)")
+	flags.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s browse [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Browse the skills interactively: type to fuzzy-filter by name or description,\n")
+		fmt.Fprintf(os.Stderr, "use the arrow keys to select and preview a skill, ctrl+e to open it in an\n")
+		fmt.Fprintf(os.Stderr, "editor, ctrl+y to copy its tool name and esc to quit.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", errNotTerminal)
+		return 1
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", err)
+		return 1
+	}
+	skillsRoot, err := regFlags.skillsRoot(argAt(positional, 0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", err)
+		return 1
+	}
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", err)
+		return 1
+	}
+
+	// Skipped skills are listed as invalid, and logs written during a
+	// rescan would garble the screen, so only log when asked to.
+	logger := slog.New(slog.DiscardHandler)
+	if verbose {
+		logger = commandLogger(true)
+	}
+	reg := registry.NewRegistry(skillsRoot, logger, regOpts...)
+	scan := func() ([]browse.Item, error) {
+		if err := reg.ScanContext(context.Background()); err != nil {
+			return nil, fmt.Errorf("scan skills: %w", err)
+		}
+		return browse.Items(reg, locale), nil
+	}
+	items, err := scan()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", err)
+		return 1
+	}
+
+	style := "light"
+	if lipgloss.HasDarkBackground() {
+		style = "dark"
+	}
+	err = browse.Run(items, browse.Options{
+		Reload: scan,
+		Copy:   copyToClipboard,
+		Editor: editor,
+		Style:  style,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills browse: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// copyToClipboard copies text with the system clipboard tool, falling back
+// to an OSC 52 escape sequence, which many terminals honor even over SSH.
+// It reports false when it fell back, since the terminal may ignore the
+// sequence and there is no way to tell.
+func copyToClipboard(text string) bool {
+	if err := clipboard.WriteAll(text); err == nil {
+		return true
+	}
+	termenv.Copy(text)
+	return false
+}
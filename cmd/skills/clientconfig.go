@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/internal/clientconfig"
+)
+
+// runClientConfig implements "skills client-config", printing or
+// installing the MCP client configuration that runs this server.
+func runClientConfig(args []string) int {
+	flags := flag.NewFlagSet("client-config", flag.ContinueOnError)
+	var (
+		name     string
+		path     string
+		write    bool
+		docker   bool
+		image    string
+		regFlags registryFlags
+	)
+	flags.StringVar(&name, "name", clientconfig.DefaultServerName, "Name to configure the server under")
+	flags.StringVar(&path, "path", "", "Client config file to write (default: the client's standard location)")
+	flags.BoolVar(&write, "write", false, "Merge the server into the client's config file, backing it up first, instead of printing it")
+	flags.BoolVar(&docker, "docker", false, "Run the server with Docker instead of this binary")
+	flags.StringVar(&image, "image", clientconfig.DefaultImage, "Docker image to run with --docker")
+	regFlags.registerRoot(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s client-config <client> [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Print the MCP configuration that runs this server for a client, or merge it\n")
+		fmt.Fprintf(os.Stderr, "into the client's config file with --write.\n\n")
+		fmt.Fprintf(os.Stderr, "Clients: %s\n\n", strings.Join(clientconfig.Names(), ", "))
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	rest, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(rest) == 0 || len(rest) > 2 {
+		flags.Usage()
+		return 2
+	}
+	clientName, rest := rest[0], rest[1:]
+
+	client, err := clientconfig.Lookup(clientName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills client-config: %v\n", err)
+		return 1
+	}
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills client-config: %v\n", err)
+		return 1
+	}
+	var rootArg string
+	if len(rest) == 1 {
+		rootArg = rest[0]
+	}
+	skillsRoot, err := regFlags.skillsRoot(rootArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills client-config: %v\n", err)
+		return 1
+	}
+
+	var srv clientconfig.Server
+	if docker {
+		srv = clientconfig.Docker(image, skillsRoot)
+	} else {
+		command, err := executablePath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills client-config: locate skills binary: %v\n", err)
+			return 1
+		}
+		srv = clientconfig.Local(command, skillsRoot)
+	}
+
+	if !write {
+		data, err := client.Render(name, srv)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills client-config: %v\n", err)
+			return 1
+		}
+		os.Stdout.Write(data)
+		return 0
+	}
+
+	if path == "" {
+		if path, err = client.Path(); err != nil {
+			fmt.Fprintf(os.Stderr, "skills client-config: locate %s config: %v\n", client.Title, err)
+			return 1
+		}
+	}
+	backup, err := client.Install(path, name, srv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills client-config: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Configured %q in %s\n", name, path)
+	if backup != "" {
+		fmt.Printf("Previous config saved to %s\n", backup)
+	}
+	fmt.Printf("Restart %s to load the server.\n", client.Title)
+	return 0
+}
+
+// executablePath returns the absolute path of the running binary, with
+// symlinks resolved.
+func executablePath() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/portertech/skills-mcp-server/internal/config"
+)
+
+// unconfigurable names the server flags that a config file or environment
+// variable cannot set: those that select the config itself or turn the
+// server into a one-off command.
+var unconfigurable = []string{"config", "version", "list", "output"}
+
+// configurable returns the names of the flags that a config file or
+// SKILLS_* environment variable can set: the server and registry flags,
+// less unconfigurable. A subcommand's own flags, such as --write or
+// --check, are never among them.
+func configurable() []string {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	var f serveFlags
+	f.register(fs)
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if !slices.Contains(unconfigurable, f.Name) {
+			names = append(names, f.Name)
+		}
+	})
+	return names
+}
+
+// loadConfig loads the config file at path, or the default one, and
+// applies it and SKILLS_* environment variables to the configurable flags
+// of fs that were not given on the command line. When strict, config keys
+// that are not configurable server settings are an error, even if fs does
+// not use them; subcommands pass false to take just the keys they share
+// with the server. It returns the loaded file, or nil if
+// there is none, and where each flag's value came from.
+func loadConfig(fs *flag.FlagSet, path string, strict bool) (*config.File, config.Sources, error) {
+	file, err := config.Resolve(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	sources, err := config.Apply(fs, file, strict, configurable()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, sources, nil
+}
+
+// runConfig implements "skills config".
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintf(os.Stderr, "Usage: %s config print [options] [skills_root]\n", os.Args[0])
+		return 2
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	var f serveFlags
+	f.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s config print [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Print the effective server configuration, merged from flags, SKILLS_*\n")
+		fmt.Fprintf(os.Stderr, "environment variables, the config file and defaults, with each value's source.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if root := flags.Arg(0); root != "" {
+		if err := flags.Set("root", root); err != nil {
+			fmt.Fprintf(os.Stderr, "skills config: %v\n", err)
+			return 1
+		}
+	}
+
+	file, sources, err := loadConfig(flags, f.registry.config, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills config: %v\n", err)
+		return 1
+	}
+	if err := config.Print(os.Stdout, flags, sources, file, unconfigurable...); err != nil {
+		fmt.Fprintf(os.Stderr, "skills config: %v\n", err)
+		return 1
+	}
+	return 0
+}
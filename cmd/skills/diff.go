@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/skilldiff"
+)
+
+// runDiff implements "skills diff", comparing two skill sets, each a
+// directory or a git ref of a skills repository.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	var (
+		repo     string
+		asJSON   bool
+		exitCode bool
+		verbose  bool
+		regFlags registryFlags
+	)
+	flags.StringVar(&repo, "repo", ".", "Git repository that refs are read from")
+	flags.BoolVar(&asJSON, "json", false, "Print the changes as JSON")
+	flags.BoolVar(&exitCode, "exit-code", false, "Exit with status 1 if the skill sets differ")
+	flags.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [options] <old> <new>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Compare two skill sets and report added, removed, renamed and modified skills,\n")
+		fmt.Fprintf(os.Stderr, "their frontmatter and tool name changes, and a unified diff of their\n")
+		fmt.Fprintf(os.Stderr, "instructions. Each side is a directory, or else a git ref of --repo such as\n")
+		fmt.Fprintf(os.Stderr, "main, v1.2.0 or HEAD~1:skills for a subdirectory.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills diff: %v\n", err)
+		return 1
+	}
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills diff: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	var regs [2]*registry.Registry
+	for i, spec := range positional {
+		root, cleanup, err := diffRoot(ctx, repo, spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills diff: %v\n", err)
+			return 1
+		}
+		defer cleanup()
+
+		regs[i] = registry.NewRegistry(root, commandLogger(verbose), regOpts...)
+		if err := regs[i].ScanContext(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "skills diff: scan %s: %v\n", spec, err)
+			return 1
+		}
+	}
+
+	res := skilldiff.Compare(regs[0], regs[1])
+	if asJSON {
+		err = skilldiff.WriteJSON(os.Stdout, res)
+	} else {
+		err = skilldiff.WriteText(os.Stdout, res)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills diff: %v\n", err)
+		return 1
+	}
+	if exitCode && res.Changed() {
+		return 1
+	}
+	return 0
+}
+
+// diffRoot returns the skills root for one side of a diff: spec itself if
+// it is a directory, else the git tree spec of repo extracted into a
+// temporary directory, which cleanup removes.
+func diffRoot(ctx context.Context, repo, spec string) (string, func(), error) {
+	if info, err := os.Stat(spec); err == nil && info.IsDir() {
+		root, err := expandPath(spec)
+		return root, func() {}, err
+	}
+
+	dir, err := os.MkdirTemp("", "skills-diff-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	if err := skilldiff.ExtractTree(ctx, repo, spec, dir); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("%s is neither a directory nor a git ref: %w", spec, err)
+	}
+	return dir, cleanup, nil
+}
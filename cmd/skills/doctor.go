@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/portertech/skills-mcp-server/internal/doctor"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+// runDoctor implements "skills doctor", diagnosing why skills are not
+// being served. It exits non-zero if any check fails.
+func runDoctor(args []string) int {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	var regFlags registryFlags
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s doctor [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Check the skills root, scan it and report skipped skills and name collisions,\n")
+		fmt.Fprintf(os.Stderr, "validate client configs that run this server, and confirm an MCP client sees\n")
+		fmt.Fprintf(os.Stderr, "the skill tools. Takes the server's options.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var checks []doctor.Check
+	report := func(c doctor.Check) {
+		checks = append(checks, c)
+		printCheck(c)
+	}
+
+	cfg, _, err := loadConfig(flags, regFlags.config, true)
+	switch {
+	case err != nil:
+		report(doctor.Check{Name: "config", Status: doctor.StatusFail, Summary: err.Error()})
+		return 1
+	case cfg != nil:
+		report(doctor.Check{Name: "config", Status: doctor.StatusOK, Summary: cfg.Path})
+	default:
+		report(doctor.Check{Name: "config", Status: doctor.StatusOK, Summary: "no config file"})
+	}
+
+	skillsRoot, err := regFlags.skillsRoot(flags.Arg(0))
+	if err != nil {
+		report(doctor.Check{Name: "skills root", Status: doctor.StatusFail, Summary: err.Error()})
+		return 1
+	}
+	root := doctor.CheckRoot(skillsRoot)
+	report(root)
+
+	regOpts, err := regFlags.options()
+	if err != nil {
+		report(doctor.Check{Name: "options", Status: doctor.StatusFail, Summary: err.Error()})
+		return 1
+	}
+
+	if root.Status != doctor.StatusFail {
+		// Skipped skills are reported by the scan check, not logged.
+		quiet := slog.New(slog.NewTextHandler(io.Discard, nil))
+		reg := registry.NewRegistry(skillsRoot, quiet, regOpts...)
+		scan := doctor.CheckScan(context.Background(), reg)
+		report(scan)
+		if scan.Status != doctor.StatusFail {
+			report(doctor.CheckCollisions(reg))
+			report(doctor.CheckHandshake(context.Background(), reg, quiet))
+		}
+	}
+
+	if executable, err := executablePath(); err == nil {
+		for _, c := range doctor.CheckClients(skillsRoot, executable) {
+			report(c)
+		}
+	}
+
+	if doctor.Worst(checks) == doctor.StatusFail {
+		return 1
+	}
+	return 0
+}
+
+// printCheck prints a check result and its details.
+func printCheck(c doctor.Check) {
+	fmt.Printf("[%-4s] %s: %s\n", c.Status, c.Name, c.Summary)
+	for _, d := range c.Details {
+		fmt.Printf("       %s\n", d)
+	}
+}
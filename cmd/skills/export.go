@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/internal/export"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// runExport implements "skills export", rendering the skills root in
+// another agent format.
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	var (
+		format   string
+		out      string
+		locale   string
+		verbose  bool
+		regFlags registryFlags
+	)
+	flags.StringVar(&format, "format", "", "Export format: "+strings.Join(export.Formats(), ", "))
+	flags.StringVar(&out, "out", "", "Directory to write to (default: stdout for single-file formats, else the current directory)")
+	flags.StringVar(&locale, "locale", "", "Export localized SKILL.<lang>.md variants for this language tag where available")
+	flags.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s export --format <format> [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Export the skills as Cursor rules (cursor), a single AGENTS.md (agents),\n")
+		fmt.Fprintf(os.Stderr, "OpenAI function tool definitions (openai) or an llms.txt index (llms-txt).\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if format == "" {
+		fmt.Fprintf(os.Stderr, "skills export: --format is required (one of %s)\n", strings.Join(export.Formats(), ", "))
+		return 2
+	}
+	exporter, err := export.Lookup(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+		return 1
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+		return 1
+	}
+
+	skillsRoot, err := regFlags.skillsRoot(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+		return 1
+	}
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+		return 1
+	}
+
+	reg := registry.NewRegistry(skillsRoot, commandLogger(verbose), regOpts...)
+	if err := reg.ScanContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: scan skills: %v\n", err)
+		return 1
+	}
+
+	var skills []*skill.Skill
+	for _, s := range reg.List() {
+		skills = append(skills, s.Localize(locale))
+	}
+	files, err := exporter.Export(export.Catalog{Root: skillsRoot, Skills: skills})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+		return 1
+	}
+
+	if out == "" && len(files) == 1 {
+		os.Stdout.Write(files[0].Data)
+		return 0
+	}
+	if out == "" {
+		out = "."
+	}
+	for _, f := range files {
+		path := filepath.Join(out, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(path, f.Data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "skills export: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+	}
+	return 0
+}
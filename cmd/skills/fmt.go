@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/skillfmt"
+)
+
+// runFmt implements "skills fmt", rewriting skill files in canonical form.
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	var (
+		check    bool
+		verbose  bool
+		regFlags registryFlags
+	)
+	flags.BoolVar(&check, "check", false, "Report files that are not formatted instead of rewriting them, exiting 1 if there are any")
+	flags.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s fmt [options] [path ...]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Rewrite skill files in canonical form: frontmatter keys in a fixed order,\n")
+		fmt.Fprintf(os.Stderr, "consistent YAML style, LF line endings and a single trailing newline.\n")
+		fmt.Fprintf(os.Stderr, "Instructions are otherwise left as written. Each path is a skill file or a\n")
+		fmt.Fprintf(os.Stderr, "directory searched for them; the default is the skills root. The files that\n")
+		fmt.Fprintf(os.Stderr, "change, or would change with --check, are printed.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills fmt: %v\n", err)
+		return 1
+	}
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills fmt: %v\n", err)
+		return 1
+	}
+	if len(positional) == 0 {
+		positional = []string{""}
+	}
+
+	var files []string
+	for _, arg := range positional {
+		if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		root, err := regFlags.skillsRoot(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills fmt: %v\n", err)
+			return 1
+		}
+		reg := registry.NewRegistry(root, commandLogger(verbose), regOpts...)
+		found, err := reg.Files(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills fmt: %v\n", err)
+			return 1
+		}
+		files = append(files, found...)
+	}
+
+	failed, unformatted := false, 0
+	for _, path := range files {
+		changed, err := formatFile(path, check)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skills fmt: %s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		if changed {
+			fmt.Println(path)
+			unformatted++
+		}
+	}
+	if check && unformatted > 0 {
+		fmt.Fprintf(os.Stderr, "%d file(s) not formatted; run skills fmt to fix\n", unformatted)
+		return 1
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// formatFile formats the skill file at path, rewriting it unless check is
+// set, and reports whether its canonical form differs.
+func formatFile(path string, check bool) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if info.Size() > registry.MaxSkillFileSize {
+		return false, fmt.Errorf("%w: %d bytes (max %d)", registry.ErrFileTooLarge, info.Size(), registry.MaxSkillFileSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := skillfmt.Format(data)
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(data, formatted) {
+		return false, nil
+	}
+	if !check {
+		if err := replaceFile(path, formatted, info.Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// replaceFile writes data to a temporary file beside path and renames it
+// into place, so a failed write leaves the original intact. A symlink at
+// path is kept and its target replaced.
+func replaceFile(path string, data []byte, mode os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+// isolateConfig keeps the user's config file and SKILLS_CONFIG out of a
+// command run by the test.
+func isolateConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", t.TempDir())
+	t.Setenv("SKILLS_CONFIG", "")
+}
+
+func TestRunFmt(t *testing.T) {
+	isolateConfig(t)
+	root := t.TempDir()
+	dir := filepath.Join(root, "review")
+	path := filepath.Join(dir, "SKILL.md")
+	unformatted := "---\ndescription: Review code\nname: review\n---\nCheck tests.\n"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(unformatted), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runFmt([]string{"--check", root}); code != 1 {
+		t.Errorf("fmt --check exit code = %d, want 1", code)
+	}
+	if data, _ := os.ReadFile(path); string(data) != unformatted {
+		t.Errorf("fmt --check changed the file:\n%s", data)
+	}
+
+	if code := runFmt([]string{root}); code != 0 {
+		t.Fatalf("fmt exit code = %d, want 0", code)
+	}
+	want := "---\nname: review\ndescription: Review code\n---\n\nCheck tests.\n"
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("formatted file =\n%s\nwant:\n%s", data, want)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0o640 {
+		t.Errorf("formatted file mode = %v, want -rw-r-----", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("skill directory has %d entries, want only SKILL.md", len(entries))
+	}
+
+	if code := runFmt([]string{"--check", root}); code != 0 {
+		t.Errorf("fmt --check of formatted files exit code = %d, want 0", code)
+	}
+}
+
+func TestRunFmtTooLarge(t *testing.T) {
+	isolateConfig(t)
+	path := filepath.Join(t.TempDir(), "large.skill.md")
+	content := "---\ndescription: Large\nname: large\n---\n" + strings.Repeat("x", registry.MaxSkillFileSize)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runFmt([]string{path}); code != 1 {
+		t.Errorf("fmt of a file over the size limit exit code = %d, want 1", code)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Error("fmt changed a file over the size limit")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/sources"
+)
+
+// runImport implements "skills import", converting other agents' rule
+// files below a directory into skill directories.
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	var (
+		formats string
+		out     string
+		force   bool
+		dryRun  bool
+	)
+	flags.StringVar(&formats, "format", "", "Comma-separated source formats to import: "+strings.Join(sources.Formats(), ", ")+" (default: all)")
+	flags.StringVar(&out, "out", "", "Directory to write skills to (default: the skills root)")
+	flags.BoolVar(&force, "force", false, "Overwrite existing skill directories")
+	flags.BoolVar(&dryRun, "dry-run", false, "Print what would be imported without writing anything")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s import [options] [dir]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Import Cursor rules, AGENTS.md, CLAUDE.md and Copilot instructions found below dir\n")
+		fmt.Fprintf(os.Stderr, "(default: the current directory) as skills.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	adapters, err := sources.Lookup(splitList(formats))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills import: %v\n", err)
+		return 1
+	}
+
+	dir := flags.Arg(0)
+	if dir == "" {
+		dir = "."
+	}
+	if out == "" {
+		out = defaultSkillsRoot()
+	}
+	if out, err = expandPath(out); err != nil {
+		fmt.Fprintf(os.Stderr, "skills import: expand output path: %v\n", err)
+		return 1
+	}
+
+	found, err := sources.Discover(dir, adapters)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills import: %v\n", err)
+		return 1
+	}
+	if len(found) == 0 {
+		fmt.Fprintf(os.Stderr, "No rule files found in %s\n", dir)
+		return 0
+	}
+
+	failed := false
+	used := make(map[string]bool)
+	for _, imp := range found {
+		if imp.Err != nil {
+			fmt.Fprintf(os.Stderr, "skip %s (%s): %v\n", imp.Rel, imp.Format, imp.Err)
+			failed = true
+			continue
+		}
+
+		name := uniqueName(imp.Skill.Name, used)
+		imp.Skill.Name = name
+		target := filepath.Join(out, name, "SKILL.md")
+
+		if _, err := os.Stat(target); err == nil && !force {
+			fmt.Fprintf(os.Stderr, "skip %s (%s): %s exists (use --force to overwrite)\n", imp.Rel, imp.Format, target)
+			continue
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "skip %s (%s): %v\n", imp.Rel, imp.Format, err)
+			failed = true
+			continue
+		}
+
+		if dryRun {
+			fmt.Printf("would import %s (%s) -> %s\n", imp.Rel, imp.Format, target)
+			continue
+		}
+		if err := writeSkill(target, imp); err != nil {
+			fmt.Fprintf(os.Stderr, "skip %s (%s): %v\n", imp.Rel, imp.Format, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("imported %s (%s) -> %s\n", imp.Rel, imp.Format, target)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// writeSkill formats an imported skill as SKILL.md and writes it to target.
+func writeSkill(target string, imp sources.Imported) error {
+	data, err := registry.FormatSkillMD(imp.Skill)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create skill directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("write skill: %w", err)
+	}
+	return nil
+}
+
+// uniqueName returns name, or name with the lowest numeric suffix not yet in
+// used, and records the result.
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + "-" + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
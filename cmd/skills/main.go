@@ -9,85 +9,130 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/portertech/skills-mcp-server/internal/catalog"
 	"github.com/portertech/skills-mcp-server/internal/registry"
 	"github.com/portertech/skills-mcp-server/internal/server"
+	"github.com/portertech/skills-mcp-server/internal/telemetry"
 )
 
 var (
 	version = "dev"
 )
 
+// commands maps subcommand names to their implementations, which take the
+// arguments after the name and return the process exit code.
+var commands = map[string]func(args []string) int{
+	"browse":        runBrowse,
+	"call":          runCall,
+	"client-config": runClientConfig,
+	"config":        runConfig,
+	"diff":          runDiff,
+	"doctor":        runDoctor,
+	"export":        runExport,
+	"fmt":           runFmt,
+	"import":        runImport,
+	"new":           runNew,
+	"show":          runShow,
+	"site":          runSite,
+}
+
 func main() {
-	var (
-		listSkills  bool
-		verbose     bool
-		showVersion bool
-	)
-
-	flag.BoolVar(&listSkills, "list", false, "List discovered skills and exit")
-	flag.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
-	flag.BoolVar(&showVersion, "version", false, "Print version and exit")
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
+	var f serveFlags
+	f.register(flag.CommandLine)
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] [skills_root]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s <command> [options] [args]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "An MCP server that exposes Claude-compatible skills as tools.\n\n")
+		fmt.Fprintf(os.Stderr, "Commands:\n")
+		fmt.Fprintf(os.Stderr, "  browse         Browse and preview the skills interactively\n")
+		fmt.Fprintf(os.Stderr, "  call           Call a skill's tool over an in-memory MCP session\n")
+		fmt.Fprintf(os.Stderr, "  client-config  Print or install the MCP configuration for a client\n")
+		fmt.Fprintf(os.Stderr, "  config         Print the effective configuration\n")
+		fmt.Fprintf(os.Stderr, "  diff           Compare two skill directories or git refs\n")
+		fmt.Fprintf(os.Stderr, "  doctor         Diagnose why skills are not being served\n")
+		fmt.Fprintf(os.Stderr, "  export         Export the skills as Cursor rules, AGENTS.md, OpenAI tools or llms.txt\n")
+		fmt.Fprintf(os.Stderr, "  fmt            Rewrite skill files in canonical form\n")
+		fmt.Fprintf(os.Stderr, "  import         Import skills from Cursor, AGENTS.md, CLAUDE.md and Copilot rule files\n")
+		fmt.Fprintf(os.Stderr, "  new            Create a skill from a template\n")
+		fmt.Fprintf(os.Stderr, "  show           Print what a skill's tool returns\n")
+		fmt.Fprintf(os.Stderr, "  site           Generate a static HTML catalog of the skills\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nDefault skills root: ~/.skills\n")
+		fmt.Fprintf(os.Stderr, "Every option can also be set in a config file (--config, or skills/config.yaml under $XDG_CONFIG_HOME)\n")
+		fmt.Fprintf(os.Stderr, "or a SKILLS_* environment variable (e.g. SKILLS_MAX_DEPTH); flags take precedence over both.\n")
+		fmt.Fprintf(os.Stderr, "In HTTP mode, /mcp, /metrics, /healthz and /readyz are served. Send SIGHUP to rescan skills.\n")
+		fmt.Fprintf(os.Stderr, "The otlp exporter honors the standard OTEL_EXPORTER_OTLP_* environment variables.\n")
 	}
 	flag.Parse()
 
-	if showVersion {
+	if f.showVersion {
 		fmt.Printf("skills %s\n", version)
 		os.Exit(0)
 	}
 
+	cfg, _, err := loadConfig(flag.CommandLine, f.registry.config, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills: %v\n", err)
+		os.Exit(1)
+	}
+
 	logLevel := slog.LevelError
-	if verbose {
+	if f.verbose {
 		logLevel = slog.LevelDebug
 	}
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: logLevel,
 	}))
 
-	skillsRoot := flag.Arg(0)
-	if skillsRoot == "" {
-		skillsRoot = defaultSkillsRoot()
+	if cfg != nil {
+		logger.Debug("loaded config file", "path", cfg.Path)
 	}
 
-	skillsRoot, err := expandPath(skillsRoot)
+	skillsRoot, err := f.registry.skillsRoot(flag.Arg(0))
 	if err != nil {
-		logger.Error("failed to expand skills root path", "error", err)
+		logger.Error("invalid skills root", "error", err)
 		os.Exit(1)
 	}
 
-	if _, err := os.Stat(skillsRoot); os.IsNotExist(err) {
-		logger.Error("skills root directory does not exist", "path", skillsRoot)
+	shutdownTelemetry, err := telemetry.Setup(context.Background(), telemetry.Config{
+		Exporter:       f.otelExporter,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		logger.Error("failed to set up telemetry", "error", err)
 		os.Exit(1)
 	}
+	defer flushTelemetry(shutdownTelemetry, logger)
 
-	reg := registry.NewRegistry(skillsRoot, logger)
-	if err := reg.Scan(); err != nil {
-		logger.Error("failed to scan skills", "error", err)
+	regOpts, err := f.registry.options()
+	if err != nil {
+		logger.Error("invalid options", "error", err)
 		os.Exit(1)
 	}
-
-	if listSkills {
-		skills := reg.List()
-		if len(skills) == 0 {
-			fmt.Println("No skills found.")
-			os.Exit(0)
+	if f.output != outputText {
+		if !f.listSkills {
+			logger.Error("invalid options", "error", "--output requires --list")
+			os.Exit(1)
 		}
-		fmt.Printf("Found %d skill(s) in %s:\n\n", len(skills), skillsRoot)
-		for _, s := range skills {
-			fmt.Printf("  %s\n", s.Name)
-			fmt.Printf("    %s\n", s.Description)
-			fmt.Printf("    Path: %s\n\n", s.Path)
+		if err := catalog.ValidateFormat(f.output); err != nil {
+			logger.Error("invalid options", "error", err)
+			os.Exit(1)
 		}
-		os.Exit(0)
 	}
 
+	reg := registry.NewRegistry(skillsRoot, logger, regOpts...)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -99,13 +144,206 @@ func main() {
 		cancel()
 	}()
 
-	srv := server.New(reg, logger)
-	if err := srv.Run(ctx); err != nil && ctx.Err() == nil {
+	if f.listSkills {
+		if err := reg.ScanContext(ctx); err != nil {
+			logger.Error("failed to scan skills", "error", err)
+			flushTelemetry(shutdownTelemetry, logger)
+			os.Exit(1)
+		}
+		skills := reg.List()
+		flushTelemetry(shutdownTelemetry, logger)
+		if f.output != outputText {
+			if err := catalog.Write(os.Stdout, f.output, catalog.Entries(reg, f.locale)); err != nil {
+				logger.Error("failed to write skills", "error", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+		if len(skills) == 0 {
+			fmt.Println("No skills found.")
+			os.Exit(0)
+		}
+		fmt.Printf("Found %d skill(s) in %s (%d tokens, %s):\n\n",
+			len(skills), skillsRoot, reg.TotalTokens(), reg.Estimator().Name())
+		for i, s := range skills {
+			indent := "  "
+			if f.registry.namespaces {
+				if i == 0 || s.Namespace != skills[i-1].Namespace {
+					fmt.Printf("%s\n", namespaceHeading(s.Namespace))
+				}
+				indent = "    "
+			}
+			fmt.Printf("%s%s (%s)\n", indent, s.Name, s.Tool)
+			fmt.Printf("%s  %s\n", indent, s.Localize(f.locale).Description)
+			fmt.Printf("%s  Path: %s\n", indent, s.Path)
+			if len(s.Lineage) > 1 {
+				fmt.Printf("%s  Extends: %s\n", indent, strings.Join(s.Lineage[1:], " -> "))
+			}
+			if len(s.Variants) > 0 {
+				fmt.Printf("%s  Locales: %s\n", indent, strings.Join(s.Locales(), ", "))
+			}
+			fmt.Printf("%s  Tokens: %d\n\n", indent, s.Localize(f.locale).Tokens)
+		}
+		printConflicts(reg.Conflicts())
+		os.Exit(0)
+	}
+
+	srvOpts := []server.Option{
+		server.WithSectionThreshold(f.sectionThreshold),
+		server.WithLocale(f.locale),
+	}
+
+	var srv *server.Server
+	if f.httpAddr != "" {
+		// Serve before the initial scan so /readyz can report its progress.
+		srv = server.New(reg, logger, srvOpts...)
+		go func() {
+			if err := reg.ScanContext(ctx); err != nil {
+				logger.Error("failed to scan skills", "error", err)
+				return
+			}
+			srv.Sync()
+		}()
+		go reloadOnHangup(ctx, reg, srv, logger)
+		err = srv.RunHTTP(ctx, f.httpAddr)
+	} else {
+		if err := reg.ScanContext(ctx); err != nil {
+			logger.Error("failed to scan skills", "error", err)
+			flushTelemetry(shutdownTelemetry, logger)
+			os.Exit(1)
+		}
+		srv = server.New(reg, logger, srvOpts...)
+		go reloadOnHangup(ctx, reg, srv, logger)
+		err = srv.Run(ctx)
+	}
+	if err != nil && ctx.Err() == nil {
 		logger.Error("server error", "error", err)
+		flushTelemetry(shutdownTelemetry, logger)
 		os.Exit(1)
 	}
 }
 
+// outputText selects the human-readable --list layout; the other --output
+// formats are the catalog's.
+const outputText = "text"
+
+// serveFlags holds the flags of the server itself, the command run when no
+// subcommand is given.
+type serveFlags struct {
+	listSkills   bool
+	output       string
+	verbose      bool
+	showVersion  bool
+	otelExporter string
+	httpAddr     string
+
+	sectionThreshold int
+	locale           string
+
+	registry registryFlags
+}
+
+// register defines the server flags on fs.
+func (f *serveFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.listSkills, "list", false, "List discovered skills and exit")
+	fs.StringVar(&f.output, "output", outputText, "Format of --list: text, "+strings.Join(catalog.Formats(), ", "))
+	fs.BoolVar(&f.verbose, "verbose", false, "Enable verbose logging")
+	fs.BoolVar(&f.showVersion, "version", false, "Print version and exit")
+	fs.StringVar(&f.httpAddr, "http", "", "Serve MCP over streamable HTTP on this address (e.g. :8080) instead of stdio")
+	fs.IntVar(&f.sectionThreshold, "section-threshold", server.DefaultSectionThreshold, "Return skills over this many tokens as a table of contents and overview (negative to disable)")
+	fs.StringVar(&f.locale, "locale", "", "Serve localized SKILL.<lang>.md variants for this language tag (e.g. ja) unless a call or client selects one")
+	f.registry.register(fs)
+	fs.StringVar(&f.otelExporter, "otel-exporter", telemetry.ExporterNone, "OpenTelemetry exporter: none, stdout or otlp")
+}
+
+// reloadOnHangup rescans the registry and resyncs the server's tools on
+// SIGHUP until ctx is cancelled. A failed rescan keeps the previous skills.
+func reloadOnHangup(ctx context.Context, reg *registry.Registry, srv *server.Server, logger *slog.Logger) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			logger.Info("reloading skills")
+			if err := reg.ScanContext(ctx); err != nil {
+				logger.Error("failed to reload skills", "error", err)
+				continue
+			}
+			srv.Sync()
+		}
+	}
+}
+
+// flushTelemetry shuts down telemetry providers, bounded so exit is never blocked
+// by an unreachable collector.
+func flushTelemetry(shutdown telemetry.ShutdownFunc, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Warn("failed to flush telemetry", "error", err)
+	}
+}
+
+// printConflicts prints the duplicate skills a scan settled, if any.
+func printConflicts(conflicts []registry.Conflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	fmt.Printf("Settled %d duplicate skill conflict(s):\n\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Printf("  %s %q (%s: %s)\n", c.Kind, c.Key, c.Policy, c.Reason)
+		fmt.Printf("    Registered: %s\n", c.Winner.File)
+		for _, s := range c.Shadowed() {
+			fmt.Printf("    Shadowed:   %s\n", s.File)
+		}
+		fmt.Println()
+	}
+}
+
+// namespaceHeading returns the --list heading for a namespace.
+func namespaceHeading(namespace string) string {
+	if namespace == "" {
+		return "  (root)"
+	}
+	return "  " + namespace
+}
+
+// parseArgs parses args with fs, allowing options after positional
+// arguments as well as before, and returns the positional arguments. A
+// "--" ends option parsing.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func defaultSkillsRoot() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
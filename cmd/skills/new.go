@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/portertech/skills-mcp-server/internal/config"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/scaffold"
+)
+
+// runNew implements "skills new", scaffolding a skill directory.
+func runNew(args []string) int {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	var (
+		opts          scaffold.Options
+		templateName  string
+		templatesDir  string
+		dir           string
+		listTemplates bool
+		regFlags      registryFlags
+	)
+	flags.StringVar(&opts.Description, "description", "", "Skill description (default: a TODO placeholder)")
+	flags.StringVar(&templateName, "template", "", "Template to create the skill from (default: the built-in template)")
+	flags.StringVar(&templatesDir, "templates", "", "Directory of templates (default: .templates under the skills root, then skills/templates under $XDG_CONFIG_HOME)")
+	flags.BoolVar(&listTemplates, "list-templates", false, "List the available templates and exit")
+	flags.StringVar(&dir, "dir", "", "Directory to create the skill in (default: the skills root)")
+	flags.BoolVar(&opts.Scripts, "scripts", false, "Add a scripts/ directory")
+	flags.BoolVar(&opts.References, "references", false, "Add a references/ directory")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s new <name> [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Create a skill directory with a SKILL.md. Names whose tool name would collide\n")
+		fmt.Fprintf(os.Stderr, "with an existing skill are rejected.\n\n")
+		fmt.Fprintf(os.Stderr, "A template is a directory with a SKILL.md, executed as a Go text/template with\n")
+		fmt.Fprintf(os.Stderr, "{{.Name}}, {{.Description}}, {{.Title}} and {{.ToolName}}, and any other files,\n")
+		fmt.Fprintf(os.Stderr, "which are copied as is.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) > 1 {
+		flags.Usage()
+		return 2
+	}
+	if len(positional) == 1 {
+		opts.Name = positional[0]
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+		return 1
+	}
+	skillsRoot, err := regFlags.skillsRoot("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+		return 1
+	}
+
+	templateDirs := []string{filepath.Join(skillsRoot, registry.TemplatesDir), filepath.Join(config.UserDir(), "templates")}
+	if templatesDir != "" {
+		if templatesDir, err = expandPath(templatesDir); err != nil {
+			fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+			return 1
+		}
+		templateDirs = []string{templatesDir}
+	}
+	if listTemplates {
+		for _, name := range scaffold.Templates(templateDirs) {
+			fmt.Println(name)
+		}
+		return 0
+	}
+
+	if opts.Name == "" {
+		flags.Usage()
+		return 2
+	}
+	if err := scaffold.ValidateName(opts.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+		return 1
+	}
+	if templateName != "" {
+		if opts.Template, err = scaffold.FindTemplate(templateDirs, templateName); err != nil {
+			fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+			return 1
+		}
+	}
+
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+		return 1
+	}
+	quiet := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reg := registry.NewRegistry(skillsRoot, quiet, regOpts...)
+	if err := reg.ScanContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: scan skills: %v\n", err)
+		return 1
+	}
+	if existing := scaffold.Collision(reg, opts.Name, regFlags.naming()); existing != "" {
+		fmt.Fprintf(os.Stderr, "skills new: %q would collide with the tool name of the skill in %s\n", opts.Name, existing)
+		return 1
+	}
+
+	parent := skillsRoot
+	if dir != "" {
+		if parent, err = expandPath(dir); err != nil {
+			fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+			return 1
+		}
+	}
+	created, err := scaffold.Create(parent, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills new: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Created %s\n", filepath.Join(created, "SKILL.md"))
+	return 0
+}
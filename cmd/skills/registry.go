@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/tokens"
+)
+
+// registryFlags holds the flags that control how skills are discovered,
+// named and budgeted, shared by the server and every subcommand that
+// scans a skills root.
+type registryFlags struct {
+	config string
+	root   string
+
+	maxSkillTokens int
+	maxTotalTokens int
+	budgetMode     string
+	tokenVocab     string
+
+	toolNaming    string
+	toolNamespace string
+	toolPrefix    string
+
+	namespaces        bool
+	includeNamespaces string
+	excludeNamespaces string
+	duplicatePolicy   string
+
+	scanWorkers int
+	scanTimeout time.Duration
+	discovery   registry.Discovery
+
+	skillFilePattern string
+}
+
+// register defines the registry flags on fs.
+func (f *registryFlags) register(fs *flag.FlagSet) {
+	f.registerRoot(fs)
+	fs.IntVar(&f.maxSkillTokens, "max-skill-tokens", 0, "Maximum estimated tokens per skill (0 for unlimited)")
+	fs.IntVar(&f.maxTotalTokens, "max-total-tokens", 0, "Maximum estimated tokens across all skills (0 for unlimited)")
+	fs.StringVar(&f.budgetMode, "token-budget-mode", "warn", "What to do with skills over a token budget: warn or reject")
+	fs.StringVar(&f.tokenVocab, "token-vocab", "", "tiktoken-format BPE vocabulary file for token counting (default: heuristic estimate)")
+	fs.StringVar(&f.toolNaming, "tool-naming", registry.NamingSnake, "How skill names become tool names: snake, kebab or namespaced")
+	fs.StringVar(&f.toolNamespace, "tool-namespace", registry.DefaultToolNamespace, "Namespace for the namespaced tool naming strategy")
+	fs.StringVar(&f.toolPrefix, "tool-prefix", "", "Prefix added to every derived tool name (e.g. skill_)")
+	fs.BoolVar(&f.namespaces, "namespaces", false, "Namespace skills by their directory relative to the root (backend/testing/SKILL.md becomes backend.testing)")
+	fs.StringVar(&f.includeNamespaces, "namespace", "", "Comma-separated namespaces to serve, including nested ones (requires --namespaces)")
+	fs.StringVar(&f.excludeNamespaces, "exclude-namespace", "", "Comma-separated namespaces not to serve, including nested ones (requires --namespaces)")
+	fs.StringVar(&f.duplicatePolicy, "duplicate-policy", registry.DuplicateFirst, "Which of several skills sharing a name or tool name to serve: first, last, error, highest-version or explicit-priority")
+	fs.IntVar(&f.scanWorkers, "scan-workers", 0, "Number of skill files to parse concurrently (default: number of CPUs)")
+	fs.DurationVar(&f.scanTimeout, "scan-timeout", 0, "Abort a skills scan that takes longer than this, keeping the previous skills (e.g. 30s; 0 for no limit)")
+	fs.IntVar(&f.discovery.MaxDepth, "max-depth", 0, "Search at most this many directories below the skills root (0 for no limit)")
+	fs.BoolVar(&f.discovery.IncludeHidden, "include-hidden", false, "Search hidden directories (version control directories are always skipped)")
+	fs.StringVar(&f.discovery.Symlinks, "symlinks", registry.SymlinksWithinRoot, "Symlink policy: ignore, within-root or follow")
+	fs.StringVar(&f.skillFilePattern, "skill-file-pattern", registry.DefaultSkillFilePattern, "Glob matching single-file skill names (empty to disable)")
+}
+
+// registerRoot defines just the --config and --root flags on fs, for
+// commands that locate the skills root without scanning it.
+func (f *registryFlags) registerRoot(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "YAML or TOML config file (default: $SKILLS_CONFIG, else skills/config.{yaml,yml,toml} under the XDG config directories)")
+	fs.StringVar(&f.root, "root", "", "Skills root directory, if not given as an argument (default: ~/.skills)")
+}
+
+// naming returns the tool naming the flags select.
+func (f *registryFlags) naming() registry.ToolNaming {
+	return registry.ToolNaming{
+		Strategy:  f.toolNaming,
+		Namespace: f.toolNamespace,
+		Prefix:    f.toolPrefix,
+	}
+}
+
+// options validates the registry flags and returns the registry options
+// they select.
+func (f *registryFlags) options() ([]registry.Option, error) {
+	if f.budgetMode != "warn" && f.budgetMode != "reject" {
+		return nil, fmt.Errorf("invalid token budget mode %q", f.budgetMode)
+	}
+	naming := f.naming()
+	if err := naming.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tool naming: %w", err)
+	}
+	if err := f.discovery.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid discovery settings: %w", err)
+	}
+	if err := registry.ValidateSkillFilePattern(f.skillFilePattern); err != nil {
+		return nil, fmt.Errorf("invalid skill file pattern: %w", err)
+	}
+	if err := registry.ValidateDuplicatePolicy(f.duplicatePolicy); err != nil {
+		return nil, fmt.Errorf("invalid duplicate policy: %w", err)
+	}
+
+	opts := []registry.Option{
+		registry.WithTokenBudget(registry.TokenBudget{
+			PerSkill: f.maxSkillTokens,
+			Total:    f.maxTotalTokens,
+			Reject:   f.budgetMode == "reject",
+		}),
+		registry.WithToolNaming(naming),
+		registry.WithDuplicatePolicy(f.duplicatePolicy),
+		registry.WithScanWorkers(f.scanWorkers),
+		registry.WithScanTimeout(f.scanTimeout),
+		registry.WithDiscovery(f.discovery),
+		registry.WithSkillFilePattern(f.skillFilePattern),
+	}
+	nsFilter := registry.NamespaceFilter{
+		Include: splitList(f.includeNamespaces),
+		Exclude: splitList(f.excludeNamespaces),
+	}
+	if !nsFilter.Empty() && !f.namespaces {
+		return nil, errors.New("--namespace and --exclude-namespace require --namespaces")
+	}
+	if f.namespaces {
+		opts = append(opts, registry.WithNamespaces(true), registry.WithNamespaceFilter(nsFilter))
+	}
+	if f.tokenVocab != "" {
+		bpe, err := tokens.LoadBPE(f.tokenVocab)
+		if err != nil {
+			return nil, fmt.Errorf("load token vocabulary: %w", err)
+		}
+		opts = append(opts, registry.WithTokenEstimator(bpe))
+	}
+	return opts, nil
+}
+
+// skillsRoot returns the skills root given as arg, falling back to --root
+// and then ~/.skills, expanded and checked to exist.
+func (f *registryFlags) skillsRoot(arg string) (string, error) {
+	if arg == "" {
+		arg = f.root
+	}
+	if arg == "" {
+		arg = defaultSkillsRoot()
+	}
+	root, err := expandPath(arg)
+	if err != nil {
+		return "", fmt.Errorf("expand skills root path: %w", err)
+	}
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return "", fmt.Errorf("skills root directory %s does not exist", root)
+	}
+	return root, nil
+}
+
+// commandLogger returns the logger for a subcommand, which reports only
+// warnings and errors unless verbose.
+func commandLogger(verbose bool) *slog.Logger {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/server"
+)
+
+// toolFlags holds the flags of the commands that exercise skill tools
+// without a client.
+type toolFlags struct {
+	sectionThreshold int
+	locale           string
+	json             bool
+	verbose          bool
+
+	registry registryFlags
+}
+
+// register defines the tool flags on fs.
+func (f *toolFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.sectionThreshold, "section-threshold", server.DefaultSectionThreshold, "Return skills over this many tokens as a table of contents and overview (negative to disable)")
+	fs.StringVar(&f.locale, "locale", "", "Serve localized SKILL.<lang>.md variants for this language tag (e.g. ja) unless the call selects one")
+	fs.BoolVar(&f.json, "json", false, "Print the tools/call result as JSON")
+	fs.BoolVar(&f.verbose, "verbose", false, "Enable verbose logging")
+	f.registry.register(fs)
+}
+
+// server loads the config, scans the skills root and returns a server for
+// it configured as "skills" would serve it.
+func (f *toolFlags) server(fs *flag.FlagSet, rootArg string) (*server.Server, error) {
+	if _, _, err := loadConfig(fs, f.registry.config, false); err != nil {
+		return nil, err
+	}
+	skillsRoot, err := f.registry.skillsRoot(rootArg)
+	if err != nil {
+		return nil, err
+	}
+	regOpts, err := f.registry.options()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := commandLogger(f.verbose)
+	reg := registry.NewRegistry(skillsRoot, logger, regOpts...)
+	if err := reg.ScanContext(context.Background()); err != nil {
+		return nil, fmt.Errorf("scan skills: %w", err)
+	}
+	return server.New(reg, logger,
+		server.WithSectionThreshold(f.sectionThreshold),
+		server.WithLocale(f.locale),
+	), nil
+}
+
+// runShow implements "skills show", printing what a skill's tool returns
+// without going through MCP.
+func runShow(args []string) int {
+	flags := flag.NewFlagSet("show", flag.ContinueOnError)
+	var (
+		section string
+		f       toolFlags
+	)
+	flags.StringVar(&section, "section", "", "Show a single section, by heading slug or title")
+	f.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s show [options] <name> [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Print the text and structured output a skill's tool returns. The skill is\n")
+		fmt.Fprintf(os.Stderr, "named by its qualified name or its tool name.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		flags.Usage()
+		return 2
+	}
+
+	srv, err := f.server(flags, argAt(positional, 1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills show: %v\n", err)
+		return 1
+	}
+	text, output, err := srv.Show(positional[0], server.SkillInput{Section: section})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills show: %v\n", err)
+		return 1
+	}
+
+	result := &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: text}},
+		StructuredContent: output,
+	}
+	if err := printToolResult(os.Stdout, result, f.json); err != nil {
+		fmt.Fprintf(os.Stderr, "skills show: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runCall implements "skills call", calling a skill's tool over MCP with an
+// in-memory client.
+func runCall(args []string) int {
+	flags := flag.NewFlagSet("call", flag.ContinueOnError)
+	var (
+		callArgs = make(toolArgs)
+		f        toolFlags
+	)
+	flags.Var(callArgs, "arg", "Tool argument as key=value, e.g. section=setup (repeatable)")
+	f.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s call [options] <tool> [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Serve the skills root over an in-memory transport, call a tool with a real\n")
+		fmt.Fprintf(os.Stderr, "MCP tools/call and print the result.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		flags.Usage()
+		return 2
+	}
+
+	srv, err := f.server(flags, argAt(positional, 1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills call: %v\n", err)
+		return 1
+	}
+	result, err := srv.Call(context.Background(), positional[0], callArgs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills call: %v\n", err)
+		return 1
+	}
+
+	out := io.Writer(os.Stdout)
+	if result.IsError && !f.json {
+		out = os.Stderr
+	}
+	if err := printToolResult(out, result, f.json); err != nil {
+		fmt.Fprintf(os.Stderr, "skills call: %v\n", err)
+		return 1
+	}
+	if result.IsError {
+		return 1
+	}
+	return 0
+}
+
+// toolArgs collects repeated key=value tool arguments.
+type toolArgs map[string]any
+
+func (a toolArgs) String() string {
+	pairs := make([]string, 0, len(a))
+	for k, v := range a {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (a toolArgs) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("want key=value, got %q", value)
+	}
+	a[k] = v
+	return nil
+}
+
+// printToolResult writes a tools/call result: as JSON, or as its text
+// content followed by its structured content.
+func printToolResult(w io.Writer, result *mcp.CallToolResult, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			fmt.Fprintln(w, text.Text)
+			continue
+		}
+		data, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+	}
+	if result.StructuredContent == nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(result.StructuredContent, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n--- structuredContent ---\n%s\n", data)
+	return nil
+}
+
+// argAt returns args[i], or "" if there are not that many.
+func argAt(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
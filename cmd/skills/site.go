@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/site"
+)
+
+// runSite implements "skills site", generating a static HTML catalog of
+// the skills root.
+func runSite(args []string) int {
+	flags := flag.NewFlagSet("site", flag.ContinueOnError)
+	var (
+		out      string
+		opts     site.Options
+		verbose  bool
+		regFlags registryFlags
+	)
+	flags.StringVar(&out, "out", "site", "Directory to write the site to")
+	flags.StringVar(&opts.Title, "title", site.DefaultTitle, "Title shown on every page")
+	flags.StringVar(&opts.Locale, "locale", "", "Render localized SKILL.<lang>.md variants for this language tag where available")
+	flags.BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	regFlags.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s site [options] [skills_root]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Generate a static HTML catalog of the skills: a searchable index with tag\n")
+		fmt.Fprintf(os.Stderr, "filters and a page per skill. Existing files in the output directory are\n")
+		fmt.Fprintf(os.Stderr, "overwritten but not removed.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flags.PrintDefaults()
+	}
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return 2
+	}
+
+	if _, _, err := loadConfig(flags, regFlags.config, false); err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: %v\n", err)
+		return 1
+	}
+	skillsRoot, err := regFlags.skillsRoot(argAt(positional, 0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: %v\n", err)
+		return 1
+	}
+	regOpts, err := regFlags.options()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: %v\n", err)
+		return 1
+	}
+
+	reg := registry.NewRegistry(skillsRoot, commandLogger(verbose), regOpts...)
+	if err := reg.ScanContext(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: scan skills: %v\n", err)
+		return 1
+	}
+
+	files, err := site.Build(reg, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: %v\n", err)
+		return 1
+	}
+	if err := site.Write(out, files); err != nil {
+		fmt.Fprintf(os.Stderr, "skills site: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d skill page(s) to %s\n", reg.Count(), out)
+	return 0
+}
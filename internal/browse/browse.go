@@ -0,0 +1,145 @@
+// Package browse implements an interactive terminal browser for the skills
+// found by a registry scan.
+package browse
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/portertech/skills-mcp-server/internal/catalog"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+// Item is a skill file shown in the browser.
+type Item struct {
+	// Entry describes the skill and its validation status.
+	Entry catalog.Entry
+
+	// Instructions are the skill's markdown instructions, empty for an
+	// invalid entry.
+	Instructions string
+}
+
+// Title returns the name the item is listed and matched under: the
+// skill's qualified name, or for an invalid entry without one, its path
+// relative to the skills root.
+func (it Item) Title() string {
+	if it.Entry.Name != "" {
+		return it.Entry.Name
+	}
+	if rel, err := filepath.Rel(it.Entry.Root, it.Entry.File); err == nil {
+		return rel
+	}
+	return it.Entry.File
+}
+
+// Items returns the items of reg's last scan in catalog order: registered
+// skills, then shadowed duplicates, then invalid files. Registered skills
+// are shown as served for locale.
+func Items(reg *registry.Registry, locale string) []Item {
+	instructions := make(map[string]string)
+	for _, s := range reg.List() {
+		instructions[s.File] = s.Localize(locale).Instructions
+	}
+	for _, c := range reg.Conflicts() {
+		for _, s := range c.Shadowed() {
+			text := s.Instructions
+			if text == "" {
+				text = s.Source
+			}
+			instructions[s.File] = text
+		}
+	}
+
+	entries := catalog.Entries(reg, locale)
+	items := make([]Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, Item{Entry: e, Instructions: instructions[e.File]})
+	}
+	return items
+}
+
+// Options configures the browser.
+type Options struct {
+	// Reload rescans the skills root. It is called after the editor exits
+	// so edits show up; nil keeps the items as they are.
+	Reload func() ([]Item, error)
+
+	// Copy copies text to the clipboard. It reports false when it could
+	// only send the text to the terminal, which may ignore it.
+	Copy func(text string) bool
+
+	// Editor is the command, with any arguments, that opens a file for
+	// editing. When blank, New sets it to DefaultEditor.
+	Editor string
+
+	// Style is the glamour style the preview is rendered in, e.g. "dark",
+	// "light" or "notty". It defaults to "dark".
+	Style string
+}
+
+// Run browses items in the terminal until the user quits.
+func Run(items []Item, opts Options) error {
+	p := tea.NewProgram(New(items, opts), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// DefaultEditor returns the editor command named by $VISUAL or $EDITOR,
+// else vi.
+func DefaultEditor() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// previewMarkdown returns the markdown the preview pane renders for it.
+func previewMarkdown(it Item) string {
+	e := it.Entry
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", it.Title())
+	if e.Description != "" {
+		fmt.Fprintf(&sb, "> %s\n\n", e.Description)
+	}
+
+	fmt.Fprintf(&sb, "- **Status:** %s\n", e.Status)
+	if e.Error != "" {
+		fmt.Fprintf(&sb, "- **Problem:** %s\n", e.Error)
+	}
+	if e.Tool != "" {
+		fmt.Fprintf(&sb, "- **Tool:** `%s`\n", e.Tool)
+	}
+	if e.Version != "" {
+		fmt.Fprintf(&sb, "- **Version:** %s\n", e.Version)
+	}
+	if e.Owner != "" {
+		fmt.Fprintf(&sb, "- **Owner:** %s\n", e.Owner)
+	}
+	if len(e.Tags) > 0 {
+		fmt.Fprintf(&sb, "- **Tags:** %s\n", strings.Join(e.Tags, ", "))
+	}
+	if len(e.Extends) > 0 {
+		fmt.Fprintf(&sb, "- **Extends:** %s\n", strings.Join(e.Extends, " -> "))
+	}
+	if len(e.Locales) > 0 {
+		fmt.Fprintf(&sb, "- **Locales:** %s\n", strings.Join(e.Locales, ", "))
+	}
+	if e.Tokens > 0 {
+		fmt.Fprintf(&sb, "- **Tokens:** %d\n", e.Tokens)
+	}
+	fmt.Fprintf(&sb, "- **File:** %s\n", e.File)
+
+	if it.Instructions != "" {
+		sb.WriteString("\n---\n\n")
+		sb.WriteString(it.Instructions)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
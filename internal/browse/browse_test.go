@@ -0,0 +1,249 @@
+package browse
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/portertech/skills-mcp-server/internal/catalog"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testItems() []Item {
+	return []Item{
+		{Entry: catalog.Entry{Name: "docker", Tool: "docker", Description: "Build container images", Status: catalog.StatusValid, File: "/s/docker/SKILL.md"}},
+		{Entry: catalog.Entry{Name: "code-review", Tool: "code_review", Description: "Review pull requests", Status: catalog.StatusValid, File: "/s/code-review/SKILL.md"}, Instructions: "Check the tests."},
+		{Entry: catalog.Entry{Name: "release", Tool: "release", Description: "Cut a release and review the changelog", Status: catalog.StatusValid, File: "/s/release/SKILL.md"}},
+		{Entry: catalog.Entry{Root: "/s", File: "/s/broken/SKILL.md", Status: catalog.StatusInvalid, Error: "parse skill: no YAML frontmatter found"}},
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		match   bool
+	}{
+		{"", "anything", true},
+		{"cr", "code-review", true},
+		{"CR", "code-review", true},
+		{"review", "code-review", true},
+		{"rc", "code-review", false},
+		{"xyz", "code-review", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.pattern, tt.text); ok != tt.match {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.pattern, tt.text, ok, tt.match)
+		}
+	}
+
+	boundary, _ := fuzzyScore("cr", "code-review")
+	scattered, _ := fuzzyScore("cr", "docker")
+	if boundary <= scattered {
+		t.Errorf("word-start match scored %d, not above scattered match %d", boundary, scattered)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	items := testItems()
+	titles := func(indexes []int) []string {
+		var got []string
+		for _, i := range indexes {
+			got = append(got, items[i].Title())
+		}
+		return got
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"", []string{"docker", "code-review", "release", filepath.Join("broken", "SKILL.md")}},
+		{"review", []string{"code-review", "release"}},
+		{"container", []string{"docker"}},
+		{"broken", []string{filepath.Join("broken", "SKILL.md")}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		if got := titles(filter(items, tt.pattern)); !slices.Equal(got, tt.want) {
+			t.Errorf("filter(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestItems(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a", "SKILL.md"), "---\nname: review\ndescription: Review\n---\n\nReview it.\n")
+	writeFile(t, filepath.Join(root, "b", "SKILL.md"), "---\nname: review\ndescription: Duplicate\n---\n\nReview again.\n")
+	writeFile(t, filepath.Join(root, "broken", "SKILL.md"), "no frontmatter\n")
+
+	reg := registry.NewRegistry(root, testLogger())
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	items := Items(reg, "")
+
+	var got []string
+	for _, it := range items {
+		got = append(got, it.Title()+":"+it.Entry.Status+":"+it.Instructions)
+	}
+	want := []string{
+		"review:valid:Review it.",
+		"review:shadowed:Review again.",
+		filepath.Join("broken", "SKILL.md") + ":invalid:",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("items = %q, want %q", got, want)
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+y":
+		return tea.KeyMsg{Type: tea.KeyCtrlY}
+	case "ctrl+e":
+		return tea.KeyMsg{Type: tea.KeyCtrlE}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModel(t *testing.T) {
+	var copied string
+	m := New(testItems(), Options{
+		Style: "notty",
+		Copy: func(text string) bool {
+			copied = text
+			return true
+		},
+	})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+
+	selected := func() string {
+		t.Helper()
+		it, ok := m.Selected()
+		if !ok {
+			return ""
+		}
+		return it.Title()
+	}
+
+	if got := selected(); got != "docker" {
+		t.Fatalf("initial selection = %q, want docker", got)
+	}
+	m.Update(key("down"))
+	if got := selected(); got != "code-review" {
+		t.Errorf("after down, selection = %q, want code-review", got)
+	}
+	if view := m.View(); !strings.Contains(view, "Check the tests.") || !strings.Contains(view, "code_review") {
+		t.Errorf("preview does not show the selected skill:\n%s", view)
+	}
+
+	m.Update(key("ctrl+y"))
+	if copied != "code_review" {
+		t.Errorf("copied %q, want code_review", copied)
+	}
+	if !strings.Contains(m.View(), "copied code_review") {
+		t.Error("copying the tool name should confirm it")
+	}
+	m.opts.Copy = func(string) bool { return false }
+	m.Update(key("ctrl+y"))
+	if !strings.Contains(m.View(), "sent code_review to the terminal clipboard") {
+		t.Error("copying through the terminal should not claim the text was copied")
+	}
+
+	for _, r := range "relse" {
+		m.Update(key(string(r)))
+	}
+	if got := selected(); got != "release" {
+		t.Errorf("after filtering, selection = %q, want release", got)
+	}
+	if view := m.View(); !strings.Contains(view, "1/4") {
+		t.Errorf("view does not count the matches:\n%s", view)
+	}
+
+	if _, cmd := m.Update(key("esc")); cmd != nil {
+		t.Error("esc with a filter should clear it, not quit")
+	}
+	if got := len(m.matches); got != 4 {
+		t.Errorf("after clearing the filter, %d matches, want 4", got)
+	}
+
+	m.Update(key("up"))
+	for range 3 {
+		m.Update(key("down"))
+	}
+	if view := m.View(); !strings.Contains(view, "no YAML frontmatter") {
+		t.Errorf("preview of an invalid skill does not show its problem:\n%s", view)
+	}
+	m.Update(key("ctrl+y"))
+	if !strings.Contains(m.View(), "has no tool name") {
+		t.Error("copying an invalid skill's tool name should explain why it cannot")
+	}
+}
+
+func TestModelReloadAfterEdit(t *testing.T) {
+	items := testItems()
+	reloaded := slices.Clone(items)
+	reloaded[1].Instructions = "Check the tests and the docs."
+
+	m := New(items, Options{
+		Style: "notty",
+		Reload: func() ([]Item, error) {
+			return reloaded, nil
+		},
+	})
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m.Update(key("down"))
+
+	m.Update(editorFinishedMsg{})
+	if it, _ := m.Selected(); it.Title() != "code-review" {
+		t.Errorf("after reload, selection = %q, want code-review", it.Title())
+	}
+	if !strings.Contains(m.View(), "and the docs") {
+		t.Error("preview not refreshed after reload")
+	}
+
+	m.opts.Reload = func() ([]Item, error) { return nil, errors.New("boom") }
+	m.Update(editorFinishedMsg{})
+	if !strings.Contains(m.View(), "rescan: boom") {
+		t.Error("reload error not shown")
+	}
+}
+
+func TestNewDefaultEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano -w")
+	for _, editor := range []string{"", "  "} {
+		if got := New(nil, Options{Editor: editor}).opts.Editor; got != "nano -w" {
+			t.Errorf("New(Editor: %q) editor = %q, want $EDITOR", editor, got)
+		}
+	}
+	if got := New(nil, Options{Editor: "code --wait"}).opts.Editor; got != "code --wait" {
+		t.Errorf("New() editor = %q, want the configured one", got)
+	}
+}
@@ -0,0 +1,83 @@
+package browse
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzyScore reports whether the runes of pattern appear in text in order,
+// ignoring case, and scores the match. Runs of consecutive runes and runes
+// at the start of a word score higher, so "cr" ranks "code-review" above
+// "docker".
+func fuzzyScore(pattern, text string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	if pattern == "" {
+		return 0, true
+	}
+
+	p := []rune(pattern)
+	var (
+		score int
+		next  int
+		prev  = -2 // index in text of the previous matched rune
+		last  rune
+	)
+	for i, r := range []rune(text) {
+		if next == len(p) {
+			break
+		}
+		if unicode.ToLower(r) == p[next] {
+			score++
+			if i == prev+1 {
+				score += 3
+			}
+			if i == 0 || !unicode.IsLetter(last) && !unicode.IsDigit(last) {
+				score += 2
+			}
+			prev = i
+			next++
+		}
+		last = r
+	}
+	if next < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// filter returns the indexes of the items matching pattern, best match
+// first. A name match outranks a description match. An empty pattern
+// matches every item in order.
+func filter(items []Item, pattern string) []int {
+	pattern = strings.TrimSpace(pattern)
+	type match struct {
+		index int
+		score int
+	}
+	var matches []match
+	for i, item := range items {
+		if pattern == "" {
+			matches = append(matches, match{index: i})
+			continue
+		}
+		score, ok := fuzzyScore(pattern, item.Title())
+		score *= 2
+		if s, matched := fuzzyScore(pattern, item.Entry.Description); matched {
+			ok = true
+			score = max(score, s)
+		}
+		if ok {
+			matches = append(matches, match{index: i, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
@@ -0,0 +1,342 @@
+package browse
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/portertech/skills-mcp-server/internal/catalog"
+)
+
+// helpText lists the keybindings in the footer.
+const helpText = "type to filter • ↑/↓ select • pgup/pgdn scroll • ctrl+e edit • ctrl+y copy tool name • esc quit"
+
+var (
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	borderStyle   = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, true, false, false).BorderForeground(lipgloss.Color("240"))
+
+	statusMarks = map[string]string{
+		catalog.StatusValid:    lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("✓"),
+		catalog.StatusShadowed: lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("~"),
+		catalog.StatusInvalid:  lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("✗"),
+	}
+)
+
+// editorFinishedMsg reports that the editor opened with ctrl+e exited.
+type editorFinishedMsg struct{ err error }
+
+// Model is the browser's Bubble Tea model.
+type Model struct {
+	items   []Item
+	matches []int // indexes into items, best match first
+	cursor  int   // index into matches
+	offset  int   // first match shown in the list
+
+	filter  textinput.Model
+	preview viewport.Model
+	width   int
+	height  int
+
+	renderer      *glamour.TermRenderer
+	rendererWidth int
+	rendered      map[string]string // preview by file, at rendererWidth
+	previewFile   string            // file shown in the preview pane
+
+	status string // message shown in place of the help until the next key
+	opts   Options
+}
+
+// New returns a browser model for items.
+func New(items []Item, opts Options) *Model {
+	if strings.TrimSpace(opts.Editor) == "" {
+		opts.Editor = DefaultEditor()
+	}
+	if opts.Style == "" {
+		opts.Style = "dark"
+	}
+
+	input := textinput.New()
+	input.Placeholder = "filter by name or description"
+	input.Focus()
+
+	m := &Model{
+		items:    items,
+		filter:   input,
+		preview:  viewport.New(0, 0),
+		rendered: make(map[string]string),
+		opts:     opts,
+	}
+	m.matches = filter(items, "")
+	return m
+}
+
+// Init implements tea.Model.
+func (m *Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update implements tea.Model.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		return m, nil
+
+	case editorFinishedMsg:
+		m.status = ""
+		if msg.err != nil {
+			m.status = fmt.Sprintf("editor: %v", msg.err)
+		}
+		if m.opts.Reload != nil {
+			items, err := m.opts.Reload()
+			if err != nil {
+				m.status = fmt.Sprintf("rescan: %v", err)
+			} else {
+				m.setItems(items)
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		m.status = ""
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if m.filter.Value() == "" {
+				return m, tea.Quit
+			}
+			m.filter.SetValue("")
+			m.refilter()
+			return m, nil
+		case "up", "ctrl+p", "ctrl+k":
+			m.move(-1)
+			return m, nil
+		case "down", "ctrl+n", "ctrl+j":
+			m.move(1)
+			return m, nil
+		case "pgup":
+			m.preview.PageUp()
+			return m, nil
+		case "pgdown":
+			m.preview.PageDown()
+			return m, nil
+		case "ctrl+e":
+			return m, m.edit()
+		case "ctrl+y":
+			m.copyTool()
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		before := m.filter.Value()
+		m.filter, cmd = m.filter.Update(msg)
+		if m.filter.Value() != before {
+			m.refilter()
+		}
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	return m, cmd
+}
+
+// View implements tea.Model.
+func (m *Model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	header := m.filter.View() + dimStyle.Render(fmt.Sprintf("  %d/%d", len(m.matches), len(m.items)))
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		borderStyle.Width(m.listWidth()).Height(m.bodyHeight()).Render(m.listView()),
+		" "+m.preview.View(),
+	)
+	footer := dimStyle.Render(helpText)
+	if m.status != "" {
+		footer = m.status
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+}
+
+// Selected returns the selected item, if any.
+func (m *Model) Selected() (Item, bool) {
+	if len(m.matches) == 0 {
+		return Item{}, false
+	}
+	return m.items[m.matches[m.cursor]], true
+}
+
+// listWidth returns the width of the list pane, excluding its border.
+func (m *Model) listWidth() int {
+	return max(20, min(48, m.width/3))
+}
+
+// bodyHeight returns the height of the list and preview panes, leaving a
+// line each for the filter and the footer.
+func (m *Model) bodyHeight() int {
+	return max(1, m.height-2)
+}
+
+// layout sizes the preview pane for the window and re-renders it.
+func (m *Model) layout() {
+	m.preview.Width = max(10, m.width-m.listWidth()-2)
+	m.preview.Height = m.bodyHeight()
+	m.previewFile = ""
+	m.updatePreview()
+}
+
+// listView renders the visible part of the list.
+func (m *Model) listView() string {
+	if len(m.matches) == 0 {
+		return dimStyle.Render("No matching skills.")
+	}
+	height := m.bodyHeight()
+	m.offset = min(m.offset, m.cursor)
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+
+	width := m.listWidth()
+	var lines []string
+	for i := m.offset; i < len(m.matches) && i < m.offset+height; i++ {
+		it := m.items[m.matches[i]]
+		title := truncate(it.Title(), width-2)
+		if i == m.cursor {
+			title = selectedStyle.Render(title)
+		}
+		lines = append(lines, statusMarks[it.Entry.Status]+" "+title)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// move moves the selection by delta, staying within the matches.
+func (m *Model) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.matches)-1, m.cursor+delta))
+	m.updatePreview()
+}
+
+// refilter recomputes the matches for the filter and selects the best.
+func (m *Model) refilter() {
+	m.matches = filter(m.items, m.filter.Value())
+	m.cursor, m.offset = 0, 0
+	m.updatePreview()
+}
+
+// setItems replaces the items, keeping the selected file selected if it is
+// still there.
+func (m *Model) setItems(items []Item) {
+	var selected string
+	if it, ok := m.Selected(); ok {
+		selected = it.Entry.File
+	}
+	m.items = items
+	m.matches = filter(items, m.filter.Value())
+	m.cursor = 0
+	for i, idx := range m.matches {
+		if items[idx].Entry.File == selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.rendered = make(map[string]string)
+	m.previewFile = ""
+	m.updatePreview()
+}
+
+// updatePreview shows the selected item in the preview pane.
+func (m *Model) updatePreview() {
+	it, ok := m.Selected()
+	if !ok {
+		m.previewFile = ""
+		m.preview.SetContent("")
+		return
+	}
+	if it.Entry.File == m.previewFile || m.preview.Width == 0 {
+		return
+	}
+	m.previewFile = it.Entry.File
+	m.preview.SetContent(m.render(it))
+	m.preview.GotoTop()
+}
+
+// render returns the rendered preview of it, cached per file and width.
+func (m *Model) render(it Item) string {
+	width := m.preview.Width
+	if m.renderer == nil || m.rendererWidth != width {
+		r, err := glamour.NewTermRenderer(glamour.WithStandardStyle(m.opts.Style), glamour.WithWordWrap(width))
+		if err != nil {
+			return previewMarkdown(it)
+		}
+		m.renderer, m.rendererWidth = r, width
+		m.rendered = make(map[string]string)
+	}
+	if out, ok := m.rendered[it.Entry.File]; ok {
+		return out
+	}
+	out, err := m.renderer.Render(previewMarkdown(it))
+	if err != nil {
+		out = previewMarkdown(it)
+	}
+	m.rendered[it.Entry.File] = out
+	return out
+}
+
+// edit opens the selected skill's file in the editor.
+func (m *Model) edit() tea.Cmd {
+	it, ok := m.Selected()
+	if !ok {
+		return nil
+	}
+	args := strings.Fields(m.opts.Editor)
+	if len(args) == 0 {
+		m.status = "no editor configured; set $EDITOR"
+		return nil
+	}
+	cmd := exec.Command(args[0], append(args[1:], it.Entry.File)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// copyTool copies the selected skill's tool name to the clipboard.
+func (m *Model) copyTool() {
+	it, ok := m.Selected()
+	switch {
+	case !ok:
+		return
+	case it.Entry.Tool == "":
+		m.status = fmt.Sprintf("%s has no tool name", it.Title())
+	case m.opts.Copy == nil:
+		m.status = "clipboard unavailable"
+	default:
+		if m.opts.Copy(it.Entry.Tool) {
+			m.status = fmt.Sprintf("copied %s", it.Entry.Tool)
+		} else {
+			m.status = fmt.Sprintf("sent %s to the terminal clipboard", it.Entry.Tool)
+		}
+	}
+}
+
+// truncate shortens s to at most width cells, marking the cut with "…".
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,219 @@
+// Package catalog describes every skill a registry scan found, registered or
+// not, in machine-readable formats for scripts and dashboards.
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownFormat is returned when an output format name is not recognized.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Validation statuses of an Entry.
+const (
+	// StatusValid marks a registered skill.
+	StatusValid = "valid"
+
+	// StatusShadowed marks a valid skill that lost a duplicate conflict.
+	StatusShadowed = "shadowed"
+
+	// StatusInvalid marks a skill, variant or path the scan skipped.
+	StatusInvalid = "invalid"
+)
+
+// Output formats.
+const (
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+	FormatNames = "names"
+)
+
+// Entry describes one skill file found by a scan.
+type Entry struct {
+	// Name is the skill's qualified name. It may be empty for an invalid
+	// entry whose file could not be parsed.
+	Name string `json:"name" yaml:"name"`
+
+	Namespace   string   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Tool        string   `json:"tool,omitempty" yaml:"tool,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Owner       string   `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// Root is the skills root the scan searched.
+	Root string `json:"root" yaml:"root"`
+
+	// Path is the skill's directory and File its markdown file. For an
+	// invalid entry, File is the path that was skipped.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	File string `json:"file" yaml:"file"`
+
+	// Hash is the hex-encoded SHA-256 digest of File and Size its size in
+	// bytes.
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+	Size int64  `json:"size,omitempty" yaml:"size,omitempty"`
+
+	// Tokens is the estimated size of the skill's resolved instructions.
+	Tokens int `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+
+	// Extends is the chain of parent skills, nearest first.
+	Extends []string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Locales []string `json:"locales,omitempty" yaml:"locales,omitempty"`
+
+	// Status is StatusValid, StatusShadowed or StatusInvalid, and Error
+	// explains why an entry is not valid.
+	Status string `json:"status" yaml:"status"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Entries describes the skills of reg's last scan: the registered skills in
+// List order, then those shadowed by duplicates, then those skipped as
+// invalid. Descriptions and token counts are those served for locale.
+func Entries(reg *registry.Registry, locale string) []Entry {
+	root := reg.Root()
+	var entries []Entry
+
+	for _, s := range reg.List() {
+		localized := s.Localize(locale)
+		e := Entry{
+			Name:        s.QualifiedName(),
+			Namespace:   s.Namespace,
+			Tool:        s.Tool,
+			Description: localized.Description,
+			Version:     s.Version,
+			Tags:        s.Tags,
+			Owner:       s.Owner,
+			Root:        root,
+			Path:        s.Path,
+			File:        s.File,
+			Hash:        s.Hash,
+			Size:        s.Size,
+			Tokens:      localized.Tokens,
+			Locales:     s.Locales(),
+			Status:      StatusValid,
+		}
+		if len(s.Lineage) > 1 {
+			e.Extends = s.Lineage[1:]
+		}
+		entries = append(entries, e)
+	}
+
+	// A skill can lose both a name and a tool name conflict; list it once.
+	seen := make(map[string]bool)
+	for _, c := range reg.Conflicts() {
+		for _, s := range c.Shadowed() {
+			if seen[s.File] {
+				continue
+			}
+			seen[s.File] = true
+			reason := c.Reason
+			if c.Winner != nil {
+				reason = fmt.Sprintf("%s %q registered from %s (%s: %s)", c.Kind, c.Key, c.Winner.File, c.Policy, c.Reason)
+			}
+			entries = append(entries, Entry{
+				Name:        s.QualifiedName(),
+				Namespace:   s.Namespace,
+				Tool:        s.Tool,
+				Description: s.Description,
+				Version:     s.Version,
+				Tags:        s.Tags,
+				Owner:       s.Owner,
+				Root:        root,
+				Path:        s.Path,
+				File:        s.File,
+				Hash:        s.Hash,
+				Size:        s.Size,
+				Status:      StatusShadowed,
+				Error:       reason,
+			})
+		}
+	}
+
+	for _, p := range reg.Problems() {
+		entries = append(entries, Entry{
+			Name:   p.Skill,
+			Root:   root,
+			File:   p.Path,
+			Status: StatusInvalid,
+			Error:  fmt.Sprintf("%s: %v", p.Reason, p.Err),
+		})
+	}
+	return entries
+}
+
+// Formats returns the names of all output formats.
+func Formats() []string {
+	return []string{FormatJSON, FormatYAML, FormatTable, FormatNames}
+}
+
+// ValidateFormat reports whether format is a known output format.
+func ValidateFormat(format string) error {
+	if !slices.Contains(Formats(), format) {
+		return fmt.Errorf("%w: %q (want one of %s)", ErrUnknownFormat, format, strings.Join(Formats(), ", "))
+	}
+	return nil
+}
+
+// Write writes entries to w in format. The names format lists only the
+// qualified names of valid entries, one per line.
+func Write(w io.Writer, format string, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(entries); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatTable:
+		return writeTable(w, entries)
+	case FormatNames:
+		for _, e := range entries {
+			if e.Status != StatusValid {
+				continue
+			}
+			if _, err := fmt.Fprintln(w, e.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ValidateFormat(format)
+}
+
+// writeTable writes entries as aligned columns with a header row.
+func writeTable(w io.Writer, entries []Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTOOL\tSTATUS\tTOKENS\tSIZE\tTAGS\tFILE")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			dash(e.Name), dash(e.Tool), e.Status, e.Tokens, e.Size, dash(strings.Join(e.Tags, ",")), e.File)
+	}
+	return tw.Flush()
+}
+
+// dash returns s, or "-" for an empty table cell.
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,158 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"gopkg.in/yaml.v3"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func scanned(t *testing.T) *registry.Registry {
+	t.Helper()
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a", "SKILL.md"), "---\nname: review\ndescription: Review code\ntags: [go, quality]\nversion: 1.2.0\n---\n\nReview.\n")
+	writeFile(t, filepath.Join(root, "b", "SKILL.md"), "---\nname: review\ndescription: Another review\n---\n\nReview again.\n")
+	writeFile(t, filepath.Join(root, "c", "SKILL.md"), "---\nname: strict-review\nextends: review\n---\n\nBe strict.\n")
+	writeFile(t, filepath.Join(root, "broken", "SKILL.md"), "no frontmatter\n")
+
+	reg := registry.NewRegistry(root, testLogger())
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+func TestEntries(t *testing.T) {
+	reg := scanned(t)
+	entries := Entries(reg, "")
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name+":"+e.Status)
+	}
+	want := []string{"review:valid", "strict-review:valid", "review:shadowed", ":invalid"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("entries = %q, want %q", got, want)
+	}
+
+	review := entries[0]
+	if review.Tool != "review" || review.Version != "1.2.0" || !slices.Equal(review.Tags, []string{"go", "quality"}) {
+		t.Errorf("review = %+v", review)
+	}
+	if review.Root != reg.Root() || review.File != filepath.Join(reg.Root(), "a", "SKILL.md") {
+		t.Errorf("review root, file = %q, %q", review.Root, review.File)
+	}
+	if len(review.Hash) != 64 || review.Size == 0 || review.Tokens == 0 {
+		t.Errorf("review hash, size, tokens = %q, %d, %d", review.Hash, review.Size, review.Tokens)
+	}
+
+	if child := entries[1]; !slices.Equal(child.Extends, []string{"review"}) || child.Description != "Review code" {
+		t.Errorf("strict-review = %+v", child)
+	}
+	if shadowed := entries[2]; !strings.Contains(shadowed.Error, review.File) {
+		t.Errorf("shadowed error = %q, want it to name %s", shadowed.Error, review.File)
+	}
+	if invalid := entries[3]; !strings.Contains(invalid.Error, registry.ErrNoFrontmatter.Error()) {
+		t.Errorf("invalid error = %q", invalid.Error)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	entries := Entries(scanned(t), "")
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatJSON, entries); err != nil {
+			t.Fatal(err)
+		}
+		var decoded []Entry
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if !slices.EqualFunc(decoded, entries, entryEqual) {
+			t.Errorf("decoded = %+v, want %+v", decoded, entries)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatYAML, entries); err != nil {
+			t.Fatal(err)
+		}
+		var decoded []Entry
+		if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("invalid YAML: %v\n%s", err, buf.String())
+		}
+		if !slices.EqualFunc(decoded, entries, entryEqual) {
+			t.Errorf("decoded = %+v, want %+v", decoded, entries)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatTable, entries); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != len(entries)+1 || !strings.HasPrefix(lines[0], "NAME") {
+			t.Fatalf("table =\n%s", buf.String())
+		}
+		if fields := strings.Fields(lines[1]); fields[2] != StatusValid || fields[5] != "go,quality" {
+			t.Errorf("first row = %q", lines[1])
+		}
+	})
+
+	t.Run("names", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatNames, entries); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "review\nstrict-review\n"; got != want {
+			t.Errorf("names = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatJSON, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.TrimSpace(buf.String()); got != "[]" {
+			t.Errorf("empty JSON = %q, want []", got)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if err := Write(&bytes.Buffer{}, "xml", entries); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("Write(xml) error = %v, want ErrUnknownFormat", err)
+		}
+	})
+}
+
+func entryEqual(a, b Entry) bool {
+	return a.Name == b.Name && a.Tool == b.Tool && a.Status == b.Status && a.File == b.File &&
+		a.Hash == b.Hash && a.Size == b.Size && a.Tokens == b.Tokens && a.Error == b.Error &&
+		slices.Equal(a.Tags, b.Tags) && slices.Equal(a.Extends, b.Extends)
+}
@@ -0,0 +1,418 @@
+// Package clientconfig generates and installs the MCP client configuration
+// that launches the skills server.
+package clientconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownClient is returned when a client name is not recognized.
+	ErrUnknownClient = errors.New("unknown client")
+
+	// ErrInvalidConfig is returned when an existing client config file
+	// cannot be merged into.
+	ErrInvalidConfig = errors.New("invalid client config")
+)
+
+// DefaultServerName is the key the server is configured under.
+const DefaultServerName = "skills"
+
+// DefaultImage is the Docker image run by Docker-based configurations.
+const DefaultImage = "portertech/skills-mcp-server:latest"
+
+// Client describes where and how an MCP client reads its server
+// configuration.
+type Client struct {
+	// Name identifies the client, e.g. "cursor".
+	Name string
+
+	// Title is the client's display name.
+	Title string
+
+	// ServersKey is the top-level key holding the server map.
+	ServersKey string
+
+	// Type, if set, is written as each server's "type".
+	Type string
+
+	// path returns the default config file location.
+	path func() (string, error)
+}
+
+// Clients returns all supported clients.
+func Clients() []Client {
+	return []Client{
+		{Name: "claude-desktop", Title: "Claude Desktop", ServersKey: "mcpServers", path: userConfigPath("Claude", "claude_desktop_config.json")},
+		{Name: "claude-code", Title: "Claude Code", ServersKey: "mcpServers", path: relativePath(".mcp.json")},
+		{Name: "cursor", Title: "Cursor", ServersKey: "mcpServers", path: homePath(".cursor", "mcp.json")},
+		{Name: "vscode", Title: "VS Code", ServersKey: "servers", Type: "stdio", path: relativePath(".vscode", "mcp.json")},
+	}
+}
+
+// Names returns the names of all supported clients.
+func Names() []string {
+	var names []string
+	for _, c := range Clients() {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Lookup returns the client with the given name.
+func Lookup(name string) (Client, error) {
+	for _, c := range Clients() {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Client{}, fmt.Errorf("%w: %q (want one of %s)", ErrUnknownClient, name, strings.Join(Names(), ", "))
+}
+
+// Path returns the client's default config file: per user for Claude
+// Desktop and Cursor, and relative to the current project for Claude Code
+// and VS Code.
+func (c Client) Path() (string, error) {
+	return c.path()
+}
+
+func userConfigPath(elem ...string) func() (string, error) {
+	return func() (string, error) {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(append([]string{dir}, elem...)...), nil
+	}
+}
+
+func homePath(elem ...string) func() (string, error) {
+	return func() (string, error) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(append([]string{home}, elem...)...), nil
+	}
+}
+
+func relativePath(elem ...string) func() (string, error) {
+	return func() (string, error) {
+		return filepath.Abs(filepath.Join(elem...))
+	}
+}
+
+// Server is the command a client runs to start the skills server.
+type Server struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// Local returns a server that runs the binary at command on root.
+func Local(command, root string) Server {
+	return Server{Command: command, Args: []string{root}}
+}
+
+// Docker returns a server that runs image with root mounted read-only.
+func Docker(image, root string) Server {
+	return Server{
+		Command: "docker",
+		Args: []string{
+			"run", "-i", "--rm",
+			"--mount", "type=bind,src=" + root + ",dst=/skills,readonly",
+			image, "/skills",
+		},
+	}
+}
+
+// Render returns a config file for c containing only the server, under
+// name.
+func (c Client) Render(name string, s Server) ([]byte, error) {
+	return c.Merge(nil, name, s)
+}
+
+// Merge adds the server under name to the existing config file data. If a
+// server of the same name exists, only its type, command and args are
+// replaced, keeping settings such as env or cwd; every other setting is
+// kept too.
+// Empty data is treated as an empty config. Keys keep their original
+// order, with a new server or servers key added last; the file is
+// re-indented with two spaces.
+func (c Client) Merge(data []byte, name string, s Server) ([]byte, error) {
+	var doc object
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	var servers object
+	if raw, ok := doc.get(c.ServersKey); ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, fmt.Errorf("%w: %q is not an object: %v", ErrInvalidConfig, c.ServersKey, err)
+		}
+	}
+
+	var entry object
+	if raw, ok := servers.get(name); ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("%w: server %q is not an object: %v", ErrInvalidConfig, name, err)
+		}
+	}
+	fields := map[string]any{"command": s.Command, "args": s.Args}
+	if c.Type != "" {
+		fields["type"] = c.Type
+	}
+	for _, key := range []string{"type", "command", "args"} {
+		value, ok := fields[key]
+		if !ok {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encode server: %w", err)
+		}
+		entry.set(key, raw)
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("encode server: %w", err)
+	}
+	servers.set(name, raw)
+
+	raw, err = json.Marshal(servers)
+	if err != nil {
+		return nil, fmt.Errorf("encode servers: %w", err)
+	}
+	doc.set(c.ServersKey, raw)
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// member is a key and its undecoded value in a JSON object.
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+// object is a JSON object that keeps its keys in their original order.
+type object []member
+
+// get returns the value of the first member named key.
+func (o object) get(key string) (json.RawMessage, bool) {
+	for _, m := range o {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// set replaces the value of the first member named key, or appends a
+// member if there is none.
+func (o *object) set(key string, value json.RawMessage) {
+	for i, m := range *o {
+		if m.key == key {
+			(*o)[i].value = value
+			return
+		}
+	}
+	*o = append(*o, member{key: key, value: value})
+}
+
+// UnmarshalJSON decodes a JSON object, keeping its members in order. null
+// decodes to an empty object.
+func (o *object) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		*o = nil
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("got %s, want an object", data)
+	}
+	*o = nil
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		*o = append(*o, member{key: tok.(string), value: value})
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// MarshalJSON encodes the object with its members in order.
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Install merges the server under name into the config file at path,
+// creating it if needed. If path is a symlink, the file it points to is
+// updated and the link kept. An existing file is first copied to a
+// timestamped .bak file, whose path is returned, and the new content is
+// written to a temporary file and renamed into place so the config is
+// never left half written.
+func (c Client) Install(path, name string, s Server) (backup string, err error) {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+
+	mode := os.FileMode(0o644)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	case errors.Is(err, os.ErrNotExist):
+		data = nil
+	default:
+		return "", fmt.Errorf("read client config: %w", err)
+	}
+
+	merged, err := c.Merge(data, name, s)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	if data != nil {
+		if backup, err = writeBackup(path, data, mode); err != nil {
+			return "", fmt.Errorf("back up client config: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return backup, fmt.Errorf("create client config directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return backup, fmt.Errorf("write client config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(merged); err != nil {
+		tmp.Close()
+		return backup, fmt.Errorf("write client config: %w", err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return backup, fmt.Errorf("write client config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return backup, fmt.Errorf("write client config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return backup, fmt.Errorf("write client config: %w", err)
+	}
+	return backup, nil
+}
+
+// writeBackup copies data to a new file named after path and the current
+// time, e.g. mcp.json.20250102150405.bak, adding a counter before .bak if
+// that file exists so an earlier backup is never overwritten.
+func writeBackup(path string, data []byte, mode os.FileMode) (string, error) {
+	base := fmt.Sprintf("%s.%s", path, time.Now().Format("20060102150405"))
+	for i := 0; ; i++ {
+		name := base + ".bak"
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d.bak", base, i)
+		}
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			os.Remove(name)
+			return "", err
+		}
+		if err := f.Close(); err != nil {
+			os.Remove(name)
+			return "", err
+		}
+		return name, nil
+	}
+}
+
+// Servers returns the servers configured in the client config file data,
+// by name.
+func (c Client) Servers(data []byte) (map[string]Server, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	servers := make(map[string]Server)
+	if raw, ok := doc[c.ServersKey]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidConfig, c.ServersKey, err)
+		}
+	}
+	return servers, nil
+}
+
+// Root returns the skills root a server runs on: the mount source of a
+// Docker server, otherwise its last argument that is not a flag. It is ""
+// if the server names no root.
+func (s Server) Root() string {
+	if s.Docker() {
+		for _, arg := range s.Args {
+			for _, opt := range strings.Split(arg, ",") {
+				if src, ok := strings.CutPrefix(opt, "src="); ok {
+					return src
+				}
+				if src, ok := strings.CutPrefix(opt, "source="); ok {
+					return src
+				}
+			}
+		}
+		return ""
+	}
+	for i := len(s.Args) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(s.Args[i], "-") {
+			return s.Args[i]
+		}
+	}
+	return ""
+}
+
+// Docker reports whether the server is run with Docker.
+func (s Server) Docker() bool {
+	return filepath.Base(s.Command) == "docker"
+}
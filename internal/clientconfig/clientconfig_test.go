@@ -0,0 +1,265 @@
+package clientconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		client string
+		server Server
+		want   string
+	}{
+		{
+			client: "cursor",
+			server: Local("/usr/local/bin/skills", "/home/me/.skills"),
+			want:   "{\n  \"mcpServers\": {\n    \"skills\": {\n      \"command\": \"/usr/local/bin/skills\",\n      \"args\": [\n        \"/home/me/.skills\"\n      ]\n    }\n  }\n}\n",
+		},
+		{
+			client: "vscode",
+			server: Local("skills", "/srv/skills"),
+			want:   "{\n  \"servers\": {\n    \"skills\": {\n      \"type\": \"stdio\",\n      \"command\": \"skills\",\n      \"args\": [\n        \"/srv/skills\"\n      ]\n    }\n  }\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.client, func(t *testing.T) {
+			c, err := Lookup(tt.client)
+			if err != nil {
+				t.Fatalf("Lookup() error: %v", err)
+			}
+			got, err := c.Render(DefaultServerName, tt.server)
+			if err != nil {
+				t.Fatalf("Render() error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocker(t *testing.T) {
+	s := Docker(DefaultImage, "/home/me/.skills")
+	want := []string{"run", "-i", "--rm", "--mount", "type=bind,src=/home/me/.skills,dst=/skills,readonly", DefaultImage, "/skills"}
+	if s.Command != "docker" || len(s.Args) != len(want) {
+		t.Fatalf("Docker() = %+v", s)
+	}
+	for i := range want {
+		if s.Args[i] != want[i] {
+			t.Errorf("Args[%d] = %q, want %q", i, s.Args[i], want[i])
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c, _ := Lookup("claude-desktop")
+	existing := `{
+  "globalShortcut": "Ctrl+Space",
+  "mcpServers": {
+    "github": {"command": "gh-mcp", "env": {"TOKEN": "x"}},
+    "skills": {"command": "old-skills", "args": [], "env": {"SKILLS_VERBOSE": "true"}, "disabled": false}
+  }
+}`
+
+	merged, err := c.Merge([]byte(existing), "skills", Local("skills", "/srv/skills"))
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+
+	var doc struct {
+		GlobalShortcut string                    `json:"globalShortcut"`
+		MCPServers     map[string]map[string]any `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("merged config is invalid: %v", err)
+	}
+	if doc.GlobalShortcut != "Ctrl+Space" {
+		t.Error("Merge() dropped an unrelated setting")
+	}
+	if env, ok := doc.MCPServers["github"]["env"].(map[string]any); !ok || env["TOKEN"] != "x" {
+		t.Errorf("Merge() changed another server: %v", doc.MCPServers["github"])
+	}
+	if doc.MCPServers["skills"]["command"] != "skills" {
+		t.Errorf("Merge() did not replace the server: %v", doc.MCPServers["skills"])
+	}
+	if env, ok := doc.MCPServers["skills"]["env"].(map[string]any); !ok || env["SKILLS_VERBOSE"] != "true" {
+		t.Errorf("Merge() dropped the server's env: %v", doc.MCPServers["skills"])
+	}
+	if disabled, ok := doc.MCPServers["skills"]["disabled"]; !ok || disabled != false {
+		t.Errorf("Merge() dropped the server's other settings: %v", doc.MCPServers["skills"])
+	}
+}
+
+func TestMergeKeepsOrder(t *testing.T) {
+	c, _ := Lookup("claude-desktop")
+	existing := `{"zoom": 2, "mcpServers": {"skills": {"command": "old"}, "github": {"command": "gh-mcp"}}, "theme": "dark"}`
+
+	merged, err := c.Merge([]byte(existing), "skills", Local("skills", "/srv/skills"))
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+	want := `{
+  "zoom": 2,
+  "mcpServers": {
+    "skills": {
+      "command": "skills",
+      "args": [
+        "/srv/skills"
+      ]
+    },
+    "github": {
+      "command": "gh-mcp"
+    }
+  },
+  "theme": "dark"
+}
+`
+	if string(merged) != want {
+		t.Errorf("Merge() =\n%s\nwant:\n%s", merged, want)
+	}
+
+	merged, err = c.Merge([]byte(`{"zoom": 2, "mcpServers": null}`), "skills", Local("skills", "/srv/skills"))
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+	if !strings.HasPrefix(string(merged), "{\n  \"zoom\": 2,\n  \"mcpServers\": {\n    \"skills\"") {
+		t.Errorf("Merge() into null servers =\n%s", merged)
+	}
+}
+
+func TestMergeInvalid(t *testing.T) {
+	c, _ := Lookup("cursor")
+	for _, data := range []string{"{", `{"mcpServers": []}`, `[]`, `{"mcpServers": {"skills": "skills"}}`} {
+		if _, err := c.Merge([]byte(data), "skills", Local("skills", "/srv")); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Merge(%s) error = %v, want ErrInvalidConfig", data, err)
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	c, _ := Lookup("claude-code")
+	path := filepath.Join(t.TempDir(), "project", ".mcp.json")
+
+	backup, err := c.Install(path, "skills", Local("skills", "/a"))
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if backup != "" {
+		t.Errorf("Install() backup = %q for a new file", backup)
+	}
+
+	first, _ := os.ReadFile(path)
+	backup, err = c.Install(path, "team", Local("skills", "/b"))
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	saved, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("backup not written: %v", err)
+	}
+	if string(saved) != string(first) {
+		t.Errorf("backup = %s, want %s", saved, first)
+	}
+
+	data, _ := os.ReadFile(path)
+	var doc map[string]map[string]Server
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc["mcpServers"]) != 2 || doc["mcpServers"]["team"].Args[0] != "/b" {
+		t.Errorf("installed config = %s", data)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 2 {
+		t.Errorf("config directory has %d entries, want the config and one backup", len(entries))
+	}
+}
+
+func TestInstallKeepsBackups(t *testing.T) {
+	c, _ := Lookup("cursor")
+	path := filepath.Join(t.TempDir(), "mcp.json")
+	original := `{"mcpServers": {}}`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Installs within the same second must not overwrite each other's
+	// backups.
+	var backups []string
+	for _, root := range []string{"/a", "/b", "/c"} {
+		backup, err := c.Install(path, "skills", Local("skills", root))
+		if err != nil {
+			t.Fatalf("Install() error: %v", err)
+		}
+		backups = append(backups, backup)
+	}
+	if backups[0] == backups[1] || backups[1] == backups[2] || backups[0] == backups[2] {
+		t.Fatalf("Install() reused a backup name: %v", backups)
+	}
+	if data, _ := os.ReadFile(backups[0]); string(data) != original {
+		t.Errorf("first backup = %s, want the original config", data)
+	}
+	if data, _ := os.ReadFile(backups[2]); !strings.Contains(string(data), `"/b"`) {
+		t.Errorf("last backup = %s, want the config from the second install", data)
+	}
+}
+
+func TestInstallSymlink(t *testing.T) {
+	c, _ := Lookup("cursor")
+	dotfiles, home := t.TempDir(), t.TempDir()
+	target := filepath.Join(dotfiles, "mcp.json")
+	if err := os.WriteFile(target, []byte(`{"mcpServers": {}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(home, "mcp.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Install(link, "skills", Local("skills", "/a")); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("Install() replaced the symlink: %v", err)
+	}
+	data, _ := os.ReadFile(target)
+	if !strings.Contains(string(data), `"/a"`) {
+		t.Errorf("symlink target = %s, want the installed server", data)
+	}
+	if info, err := os.Stat(target); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("symlink target mode = %v, want -rw-------", info.Mode().Perm())
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("emacs"); !errors.Is(err, ErrUnknownClient) {
+		t.Errorf("Lookup(emacs) error = %v, want ErrUnknownClient", err)
+	}
+}
+
+func TestServerRoot(t *testing.T) {
+	tests := []struct {
+		server Server
+		want   string
+	}{
+		{Local("skills", "/srv/skills"), "/srv/skills"},
+		{Server{Command: "skills", Args: []string{"--http", ":8080", "/srv/skills"}}, "/srv/skills"},
+		{Server{Command: "skills", Args: []string{"--verbose"}}, ""},
+		{Docker(DefaultImage, "/srv/skills"), "/srv/skills"},
+		{Server{Command: "/usr/bin/docker", Args: []string{"run", "--mount", "type=bind,source=/a,target=/skills", "img"}}, "/a"},
+	}
+	for _, tt := range tests {
+		if got := tt.server.Root(); got != tt.want {
+			t.Errorf("%+v Root() = %q, want %q", tt.server, got, tt.want)
+		}
+	}
+}
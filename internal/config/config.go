@@ -0,0 +1,270 @@
+// Package config layers a configuration file and SKILLS_* environment
+// variables beneath command-line flags.
+//
+// Settings are keyed by flag name: the file key max-skill-tokens (or
+// max_skill_tokens) and the environment variable SKILLS_MAX_SKILL_TOKENS
+// both set --max-skill-tokens. Precedence is flags, then environment, then
+// file, then the flag's default.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnknownKey is returned when a config file sets a key that is not a
+	// known flag.
+	ErrUnknownKey = errors.New("unknown config key")
+
+	// ErrInvalidValue is returned when a config value cannot be applied to
+	// its flag.
+	ErrInvalidValue = errors.New("invalid config value")
+)
+
+// EnvPrefix prefixes the environment variable for each flag.
+const EnvPrefix = "SKILLS_"
+
+// EnvConfig names the environment variable that selects the config file
+// when --config is not given.
+const EnvConfig = EnvPrefix + "CONFIG"
+
+// Source identifies where a setting's effective value came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// File is a parsed configuration file.
+type File struct {
+	// Path is the file the values were read from.
+	Path string
+
+	// Values maps normalized keys to flag values. Lists are joined with
+	// commas, matching the flags that accept them.
+	Values map[string]string
+}
+
+// Load reads a YAML or TOML configuration file, chosen by its extension
+// (.toml for TOML, anything else YAML). Keys are flag names; underscores
+// may be used in place of hyphens.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := make(map[string]any)
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	f := &File{Path: path, Values: make(map[string]string, len(raw))}
+	for key, value := range raw {
+		s, err := stringValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s in %s: %v", ErrInvalidValue, key, path, err)
+		}
+		f.Values[normalizeKey(key)] = s
+	}
+	return f, nil
+}
+
+// stringValue converts a decoded config value to its flag syntax.
+func stringValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := stringValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// normalizeKey maps a config key to its flag name.
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "_", "-")
+}
+
+// EnvName returns the environment variable for a flag, e.g.
+// SKILLS_MAX_SKILL_TOKENS for max-skill-tokens.
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// fileNames are the config file names searched for in each directory.
+var fileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// SearchPaths returns the default config file locations in order of
+// preference: skills/config.{yaml,yml,toml} under $XDG_CONFIG_HOME
+// (default ~/.config), then under each of $XDG_CONFIG_DIRS (default
+// /etc/xdg).
+func SearchPaths() []string {
+	var dirs []string
+	if home := userConfigHome(); home != "" {
+		dirs = append(dirs, home)
+	}
+	configDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if configDirs == "" {
+		configDirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(configDirs) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		for _, name := range fileNames {
+			paths = append(paths, filepath.Join(dir, "skills", name))
+		}
+	}
+	return paths
+}
+
+// UserDir returns the per-user skills configuration directory,
+// $XDG_CONFIG_HOME/skills (default ~/.config/skills), or "" if the home
+// directory is unknown.
+func UserDir() string {
+	home := userConfigHome()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, "skills")
+}
+
+// userConfigHome returns $XDG_CONFIG_HOME, defaulting to ~/.config.
+func userConfigHome() string {
+	if home := os.Getenv("XDG_CONFIG_HOME"); home != "" {
+		return home
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config")
+}
+
+// Find returns the first existing file among SearchPaths, or "" if there
+// is none.
+func Find() string {
+	for _, path := range SearchPaths() {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Resolve loads the config file at path, or when path is empty, the one
+// named by SKILLS_CONFIG or found by Find. It returns nil when no path was
+// given and no default file exists.
+func Resolve(path string) (*File, error) {
+	if path == "" {
+		path = os.Getenv(EnvConfig)
+	}
+	if path == "" {
+		path = Find()
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return Load(path)
+}
+
+// Sources maps flag names to where their effective values came from.
+type Sources map[string]Source
+
+// Apply sets every flag in fs that is named in keys and was not given on
+// the command line from its SKILLS_* environment variable or, failing that,
+// from file, which may be nil. Other flags are left as parsed and have no
+// source, so a command's own flags cannot be set from the environment or a
+// config file. Keys in file that are not named in keys are ignored unless
+// strict, in which case they are an error; named keys that fs does not
+// define are valid settings that fs does not use.
+func Apply(fs *flag.FlagSet, file *File, strict bool, keys ...string) (Sources, error) {
+	allowed := make(map[string]bool, len(keys))
+	for _, name := range keys {
+		allowed[name] = true
+	}
+
+	sources := make(Sources)
+	fs.Visit(func(f *flag.Flag) {
+		if allowed[f.Name] {
+			sources[f.Name] = SourceFlag
+		}
+	})
+
+	var errs []error
+	fs.VisitAll(func(f *flag.Flag) {
+		if !allowed[f.Name] || sources[f.Name] == SourceFlag {
+			return
+		}
+		if value, ok := os.LookupEnv(EnvName(f.Name)); ok {
+			if err := fs.Set(f.Name, value); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s=%q: %v", ErrInvalidValue, EnvName(f.Name), value, err))
+			}
+			sources[f.Name] = SourceEnv
+			return
+		}
+		if file != nil {
+			if value, ok := file.Values[f.Name]; ok {
+				if err := fs.Set(f.Name, value); err != nil {
+					errs = append(errs, fmt.Errorf("%w: %s: %q in %s: %v", ErrInvalidValue, f.Name, value, file.Path, err))
+				}
+				sources[f.Name] = SourceFile
+				return
+			}
+		}
+		sources[f.Name] = SourceDefault
+	})
+
+	if strict && file != nil {
+		var unknown []string
+		for key := range file.Values {
+			if !allowed[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = append(errs, fmt.Errorf("%w: %s in %s", ErrUnknownKey, key, file.Path))
+		}
+	}
+
+	return sources, errors.Join(errs...)
+}
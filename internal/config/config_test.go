@@ -0,0 +1,249 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testFlags struct {
+	depth     int
+	timeout   time.Duration
+	naming    string
+	namespace string
+	hidden    bool
+	config    string
+}
+
+func newFlagSet(f *testFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.IntVar(&f.depth, "max-depth", 0, "")
+	fs.DurationVar(&f.timeout, "scan-timeout", 0, "")
+	fs.StringVar(&f.naming, "tool-naming", "snake", "")
+	fs.StringVar(&f.namespace, "namespace", "", "")
+	fs.BoolVar(&f.hidden, "include-hidden", false, "")
+	fs.StringVar(&f.config, "config", "", "")
+	return fs
+}
+
+// keys are the configurable flags of newFlagSet.
+var keys = []string{"max-depth", "scan-timeout", "tool-naming", "namespace", "include-hidden"}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	want := map[string]string{
+		"max-depth":      "3",
+		"scan-timeout":   "30s",
+		"namespace":      "backend,frontend",
+		"include-hidden": "true",
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "config.yaml", "max_depth: 3\nscan-timeout: 30s\nnamespace: [backend, frontend]\ninclude-hidden: true\n"},
+		{"toml", "config.toml", "max_depth = 3\nscan-timeout = \"30s\"\nnamespace = [\"backend\", \"frontend\"]\ninclude-hidden = true\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Load(writeConfig(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if len(f.Values) != len(want) {
+				t.Errorf("Values = %v, want %v", f.Values, want)
+			}
+			for key, value := range want {
+				if f.Values[key] != value {
+					t.Errorf("Values[%s] = %q, want %q", key, f.Values[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadNested(t *testing.T) {
+	_, err := Load(writeConfig(t, "config.yaml", "naming:\n  strategy: kebab\n"))
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Load() error = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestApplyPrecedence(t *testing.T) {
+	file := &File{Path: "config.yaml", Values: map[string]string{
+		"max-depth":    "3",
+		"scan-timeout": "30s",
+		"tool-naming":  "kebab",
+	}}
+	t.Setenv("SKILLS_MAX_DEPTH", "5")
+	t.Setenv("SKILLS_TOOL_NAMING", "namespaced")
+
+	var f testFlags
+	fs := newFlagSet(&f)
+	if err := fs.Parse([]string{"--tool-naming", "snake"}); err != nil {
+		t.Fatal(err)
+	}
+	sources, err := Apply(fs, file, true, keys...)
+	if err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+
+	if f.naming != "snake" || sources["tool-naming"] != SourceFlag {
+		t.Errorf("tool-naming = %q from %s, want snake from flag", f.naming, sources["tool-naming"])
+	}
+	if f.depth != 5 || sources["max-depth"] != SourceEnv {
+		t.Errorf("max-depth = %d from %s, want 5 from env", f.depth, sources["max-depth"])
+	}
+	if f.timeout != 30*time.Second || sources["scan-timeout"] != SourceFile {
+		t.Errorf("scan-timeout = %s from %s, want 30s from file", f.timeout, sources["scan-timeout"])
+	}
+	if f.hidden || sources["include-hidden"] != SourceDefault {
+		t.Errorf("include-hidden = %t from %s, want false from default", f.hidden, sources["include-hidden"])
+	}
+	if _, ok := sources["config"]; ok {
+		t.Error("skipped flag should have no source")
+	}
+}
+
+func TestApplyIgnoresOtherFlags(t *testing.T) {
+	t.Setenv("SKILLS_WRITE", "true")
+	t.Setenv("SKILLS_CONFIG", "other.yaml")
+
+	var f testFlags
+	fs := newFlagSet(&f)
+	var write bool
+	fs.BoolVar(&write, "write", false, "")
+	file := &File{Path: "config.yaml", Values: map[string]string{"write": "true", "max-depth": "2"}}
+
+	sources, err := Apply(fs, file, false, keys...)
+	if err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if write {
+		t.Error("write was set from the environment or config file")
+	}
+	if f.config != "" {
+		t.Errorf("config = %q, want it left unset", f.config)
+	}
+	if _, ok := sources["write"]; ok {
+		t.Error("unconfigurable flag should have no source")
+	}
+	if f.depth != 2 {
+		t.Errorf("max-depth = %d, want 2 from file", f.depth)
+	}
+
+	if _, err := Apply(newFlagSet(&f), file, true, keys...); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("strict Apply() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]string
+		env     string
+		strict  bool
+		wantErr error
+	}{
+		{name: "unknown key strict", values: map[string]string{"bogus": "1"}, strict: true, wantErr: ErrUnknownKey},
+		{name: "unknown key lenient", values: map[string]string{"bogus": "1"}},
+		{name: "skipped key strict", values: map[string]string{"config": "other.yaml"}, strict: true, wantErr: ErrUnknownKey},
+		{name: "key unused by flag set strict", values: map[string]string{"http": ":8080"}, strict: true},
+		{name: "invalid file value", values: map[string]string{"max-depth": "deep"}, wantErr: ErrInvalidValue},
+		{name: "invalid env value", env: "deep", wantErr: ErrInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("SKILLS_MAX_DEPTH", tt.env)
+			}
+			var f testFlags
+			fs := newFlagSet(&f)
+			_, err := Apply(fs, &File{Path: "config.yaml", Values: tt.values}, tt.strict, append(keys, "http")...)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Apply() error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Apply() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("XDG_CONFIG_DIRS", t.TempDir())
+	t.Setenv(EnvConfig, "")
+
+	f, err := Resolve("")
+	if err != nil || f != nil {
+		t.Fatalf("Resolve() = %v, %v; want no file", f, err)
+	}
+
+	path := filepath.Join(home, "skills", "config.toml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("max-depth = 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err = Resolve("")
+	if err != nil || f == nil || f.Path != path {
+		t.Fatalf("Resolve() = %v, %v; want %s", f, err, path)
+	}
+
+	t.Setenv(EnvConfig, filepath.Join(home, "missing.yaml"))
+	if _, err := Resolve(""); err == nil {
+		t.Error("Resolve() should fail for a missing SKILLS_CONFIG file")
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var f testFlags
+	fs := newFlagSet(&f)
+	if err := fs.Parse([]string{"--max-depth", "2", "--scan-timeout", "1m"}); err != nil {
+		t.Fatal(err)
+	}
+	sources, err := Apply(fs, nil, true, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Print(&buf, fs, sources, nil, "config"); err != nil {
+		t.Fatalf("Print() error: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"# Config file: none\n",
+		"max-depth: 2 # flag\n",
+		"scan-timeout: 1m0s # flag\n",
+		"include-hidden: false # default\n",
+		"tool-naming: snake # default\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Print() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "config:") {
+		t.Errorf("Print() should skip config:\n%s", got)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Print writes the effective value of every flag in fs, except those named
+// in skip, as a YAML config file. Each value is commented with its source,
+// and the file it was loaded from, if any, is noted at the top, so the
+// output both explains the configuration and can be saved as a config file.
+func Print(w io.Writer, fs *flag.FlagSet, sources Sources, file *File, skip ...string) error {
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	fs.VisitAll(func(f *flag.Flag) {
+		if skipped[f.Name] {
+			return
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(flagValue(f)); err != nil {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: f.Value.String()}
+		}
+		source := sources[f.Name]
+		if source == "" {
+			source = SourceDefault
+		}
+		switch source {
+		case SourceEnv:
+			value.LineComment = string(source) + " (" + EnvName(f.Name) + ")"
+		default:
+			value.LineComment = string(source)
+		}
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.Name}, value)
+	})
+
+	if file != nil {
+		doc.HeadComment = "Config file: " + file.Path
+	} else {
+		doc.HeadComment = "Config file: none"
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	return enc.Close()
+}
+
+// flagValue returns a flag's value with its type, so numbers and booleans
+// print unquoted. Durations print in flag syntax.
+func flagValue(f *flag.Flag) any {
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return f.Value.String()
+	}
+	switch v := getter.Get().(type) {
+	case time.Duration:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,337 @@
+// Package doctor diagnoses why skills are not being served: a missing or
+// unreadable root, skills the scan skipped, colliding tool names, client
+// configurations that point at the wrong place, and a server that does
+// not list the expected tools.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/portertech/skills-mcp-server/internal/clientconfig"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/server"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Check is the result of one diagnostic.
+type Check struct {
+	// Name is a short description of what was checked.
+	Name string
+
+	// Status is the outcome.
+	Status Status
+
+	// Summary is a one-line result.
+	Summary string
+
+	// Details lists individual findings, such as each skipped skill.
+	Details []string
+}
+
+// HandshakeTimeout bounds the in-process MCP handshake.
+const HandshakeTimeout = 10 * time.Second
+
+// CheckRoot checks that the skills root exists and is a readable,
+// searchable directory.
+func CheckRoot(root string) Check {
+	c := Check{Name: "skills root"}
+	info, err := os.Stat(root)
+	switch {
+	case os.IsNotExist(err):
+		c.Status, c.Summary = StatusFail, root+" does not exist"
+		return c
+	case err != nil:
+		c.Status, c.Summary = StatusFail, err.Error()
+		return c
+	case !info.IsDir():
+		c.Status, c.Summary = StatusFail, root+" is not a directory"
+		return c
+	}
+	if _, err := os.ReadDir(root); err != nil {
+		c.Status, c.Summary = StatusFail, fmt.Sprintf("cannot list %s: %v", root, err)
+		return c
+	}
+	c.Status, c.Summary = StatusOK, fmt.Sprintf("%s (mode %s)", root, info.Mode().Perm())
+	if info.Mode().Perm()&0o002 != 0 {
+		c.Status = StatusWarn
+		c.Details = append(c.Details, "the root is world-writable, so any user can add skills")
+	}
+	return c
+}
+
+// CheckScan runs a full scan of reg and reports the skills it found and
+// every skill or path it skipped.
+func CheckScan(ctx context.Context, reg *registry.Registry) Check {
+	c := Check{Name: "scan"}
+	if err := reg.ScanContext(ctx); err != nil {
+		c.Status, c.Summary = StatusFail, err.Error()
+		return c
+	}
+
+	problems := reg.Problems()
+	c.Summary = fmt.Sprintf("%d skill(s), %d tokens, %d skipped", reg.Count(), reg.TotalTokens(), len(problems))
+	for _, p := range problems {
+		c.Details = append(c.Details, fmt.Sprintf("%s: %s: %v", p.Path, p.Reason, p.Err))
+	}
+
+	switch {
+	case reg.Count() == 0:
+		c.Status = StatusWarn
+		c.Details = append(c.Details, "no skills found; skills are directories with a SKILL.md or *.skill.md files")
+	case len(problems) > 0:
+		c.Status = StatusWarn
+	default:
+		c.Status = StatusOK
+	}
+	return c
+}
+
+// CheckCollisions reports skills that were shadowed because they share a
+// qualified name or tool name with another skill. It requires a completed
+// scan.
+func CheckCollisions(reg *registry.Registry) Check {
+	c := Check{Name: "name collisions", Status: StatusOK, Summary: "none"}
+	conflicts := reg.Conflicts()
+	if len(conflicts) == 0 {
+		return c
+	}
+
+	var tools int
+	for _, conflict := range conflicts {
+		if conflict.Kind == registry.ConflictToolName {
+			tools++
+		}
+		files := make([]string, 0, len(conflict.Candidates))
+		for _, s := range conflict.Candidates {
+			files = append(files, s.File)
+		}
+		c.Details = append(c.Details, fmt.Sprintf("%s %q: %s (%s)",
+			conflict.Kind, conflict.Key, strings.Join(files, ", "), conflict.Reason))
+	}
+	c.Status = StatusWarn
+	c.Summary = fmt.Sprintf("%d conflict(s), %d on tool names", len(conflicts), tools)
+	return c
+}
+
+// CheckClients validates each client config file that exists in its
+// standard location and configures this server, identified by a command
+// named like the running binary or a Docker image named like
+// clientconfig.DefaultImage. It reports servers whose command or root is
+// missing, and those serving a root other than root.
+func CheckClients(root, executable string) []Check {
+	var checks []Check
+	for _, client := range clientconfig.Clients() {
+		path, err := client.Path()
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		c := Check{Name: client.Title + " config", Status: StatusOK}
+		servers, err := client.Servers(data)
+		if err != nil {
+			c.Status, c.Summary = StatusFail, fmt.Sprintf("%s: %v", path, err)
+			checks = append(checks, c)
+			continue
+		}
+
+		var names []string
+		for name, s := range servers {
+			if isSkillsServer(s, executable) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		if len(names) == 0 {
+			c.Status, c.Summary = StatusWarn, path+" does not configure the skills server"
+			checks = append(checks, c)
+			continue
+		}
+
+		c.Summary = fmt.Sprintf("%s configures %s", path, strings.Join(names, ", "))
+		for _, name := range names {
+			for _, problem := range serverProblems(servers[name], root) {
+				c.Details = append(c.Details, fmt.Sprintf("%s: %s", name, problem))
+				c.Status = worse(c.Status, problem.status)
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+// serverProblem is a finding about a configured server.
+type serverProblem struct {
+	status  Status
+	message string
+}
+
+func (p serverProblem) String() string { return p.message }
+
+// serverProblems checks that a configured server's command and root exist.
+func serverProblems(s clientconfig.Server, root string) []serverProblem {
+	var problems []serverProblem
+	if !s.Docker() {
+		if _, err := lookPath(s.Command); err != nil {
+			problems = append(problems, serverProblem{StatusFail, fmt.Sprintf("command %s not found", s.Command)})
+		}
+	}
+
+	serverRoot := s.Root()
+	switch {
+	case serverRoot == "":
+		problems = append(problems, serverProblem{StatusWarn, "no skills root given; the server uses its default"})
+	case !filepath.IsAbs(serverRoot):
+		problems = append(problems, serverProblem{StatusWarn, fmt.Sprintf("skills root %s is relative to the client's working directory", serverRoot)})
+	default:
+		if _, err := os.Stat(serverRoot); err != nil {
+			problems = append(problems, serverProblem{StatusFail, fmt.Sprintf("skills root %s does not exist", serverRoot)})
+		} else if filepath.Clean(serverRoot) != filepath.Clean(root) {
+			problems = append(problems, serverProblem{StatusWarn, fmt.Sprintf("serves %s, not %s", serverRoot, root)})
+		}
+	}
+	return problems
+}
+
+// isSkillsServer reports whether a configured server runs this server.
+func isSkillsServer(s clientconfig.Server, executable string) bool {
+	if s.Docker() {
+		image, _, _ := strings.Cut(clientconfig.DefaultImage, ":")
+		for _, arg := range s.Args {
+			if strings.Contains(arg, filepath.Base(image)) {
+				return true
+			}
+		}
+		return false
+	}
+	return s.Command == executable || filepath.Base(s.Command) == filepath.Base(executable)
+}
+
+// lookPath finds a configured command, as the client would.
+func lookPath(command string) (string, error) {
+	if filepath.IsAbs(command) {
+		_, err := os.Stat(command)
+		return command, err
+	}
+	return exec.LookPath(command)
+}
+
+// CheckHandshake serves reg in-process over an in-memory transport,
+// connects an MCP client, and checks that it lists a tool for every skill.
+// It requires a completed scan.
+func CheckHandshake(ctx context.Context, reg *registry.Registry, logger *slog.Logger) Check {
+	c := Check{Name: "MCP handshake"}
+
+	ctx, cancel := context.WithTimeout(ctx, HandshakeTimeout)
+	defer cancel()
+
+	srv := server.New(reg, logger)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.RunWithTransport(ctx, serverTransport)
+	}()
+
+	// fail reports err from the named step, or the server's own error if
+	// it has stopped, since that is what made the step fail.
+	fail := func(step string, err error) Check {
+		select {
+		case serr := <-serverErr:
+			if serr != nil {
+				step, err = "server", serr
+			}
+		default:
+		}
+		c.Status, c.Summary = StatusFail, fmt.Sprintf("%s: %v", step, err)
+		return c
+	}
+
+	type connection struct {
+		session *mcp.ClientSession
+		err     error
+	}
+	connected := make(chan connection, 1)
+	go func() {
+		client := mcp.NewClient(&mcp.Implementation{Name: "skills-doctor", Version: "1.0.0"}, nil)
+		session, err := client.Connect(ctx, clientTransport, nil)
+		connected <- connection{session, err}
+	}()
+
+	var session *mcp.ClientSession
+	select {
+	case err := <-serverErr:
+		if err == nil {
+			err = errors.New("stopped during initialization")
+		}
+		c.Status, c.Summary = StatusFail, fmt.Sprintf("server: %v", err)
+		return c
+	case conn := <-connected:
+		if conn.err != nil {
+			return fail("connect", conn.err)
+		}
+		session = conn.session
+	}
+	defer session.Close()
+
+	listed := make(map[string]bool)
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return fail("list tools", err)
+		}
+		listed[tool.Name] = true
+	}
+
+	var missing []string
+	for _, s := range reg.List() {
+		if !listed[s.Tool] {
+			missing = append(missing, s.Tool)
+		}
+	}
+	c.Summary = fmt.Sprintf("%d tool(s) listed", len(listed))
+	if len(missing) > 0 {
+		c.Status = StatusFail
+		c.Details = append(c.Details, "not listed: "+strings.Join(missing, ", "))
+		return c
+	}
+	c.Status = StatusOK
+	return c
+}
+
+// worse returns the more severe of two statuses.
+func worse(a, b Status) Status {
+	rank := map[Status]int{StatusOK: 0, StatusWarn: 1, StatusFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// Worst returns the most severe status among checks.
+func Worst(checks []Check) Status {
+	status := StatusOK
+	for _, c := range checks {
+		status = worse(status, c.Status)
+	}
+	return status
+}
@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestCheckRoot(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	writeFile(t, file, "x")
+
+	tests := []struct {
+		name string
+		root string
+		want Status
+	}{
+		{"directory", dir, StatusOK},
+		{"missing", filepath.Join(dir, "missing"), StatusFail},
+		{"file", file, StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckRoot(tt.root); got.Status != tt.want {
+				t.Errorf("CheckRoot() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksAfterScan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a", "SKILL.md"), "---\nname: git-flow\ndescription: A\n---\n\nA.\n")
+	writeFile(t, filepath.Join(root, "b", "SKILL.md"), "---\nname: git_flow\ndescription: B\n---\n\nB.\n")
+	writeFile(t, filepath.Join(root, "review", "SKILL.md"), "---\nname: review\ndescription: Review\n---\n\nReview.\n")
+	writeFile(t, filepath.Join(root, "broken", "SKILL.md"), "no frontmatter\n")
+
+	reg := registry.NewRegistry(root, testLogger())
+
+	scan := CheckScan(context.Background(), reg)
+	if scan.Status != StatusWarn || len(scan.Details) != 1 || !strings.Contains(scan.Details[0], "broken") {
+		t.Errorf("CheckScan() = %+v, want a warning about the broken skill", scan)
+	}
+
+	collisions := CheckCollisions(reg)
+	if collisions.Status != StatusWarn || len(collisions.Details) != 1 || !strings.Contains(collisions.Details[0], `tool-name "git_flow"`) {
+		t.Errorf("CheckCollisions() = %+v, want the git_flow collision", collisions)
+	}
+
+	handshake := CheckHandshake(context.Background(), reg, testLogger())
+	if handshake.Status != StatusOK || handshake.Summary != "2 tool(s) listed" {
+		t.Errorf("CheckHandshake() = %+v", handshake)
+	}
+}
+
+func TestCheckClients(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Chdir(t.TempDir())
+
+	root := t.TempDir()
+	other := t.TempDir()
+	writeFile(t, filepath.Join(home, ".cursor", "mcp.json"), `{"mcpServers": {
+  "skills": {"command": "/opt/bin/skills", "args": ["`+other+`"]},
+  "broken": {"command": "/opt/bin/skills", "args": ["/missing"]},
+  "github": {"command": "gh-mcp"}
+}}`)
+	writeFile(t, ".vscode/mcp.json", `{"servers": {"github": {"command": "gh-mcp"}}}`)
+	writeFile(t, ".mcp.json", `{"mcpServers": [`)
+
+	checks := CheckClients(root, "/opt/bin/skills")
+	byName := make(map[string]Check)
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+
+	cursor := byName["Cursor config"]
+	if cursor.Status != StatusFail || !strings.Contains(cursor.Summary, "configures broken, skills") {
+		t.Errorf("Cursor check = %+v", cursor)
+	}
+	details := strings.Join(cursor.Details, "\n")
+	for _, want := range []string{
+		"broken: command /opt/bin/skills not found",
+		"broken: skills root /missing does not exist",
+		"skills: serves " + other + ", not " + root,
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("Cursor details missing %q:\n%s", want, details)
+		}
+	}
+
+	if c := byName["VS Code config"]; c.Status != StatusWarn {
+		t.Errorf("VS Code check = %+v, want a warning that skills is not configured", c)
+	}
+	if c := byName["Claude Code config"]; c.Status != StatusFail {
+		t.Errorf("Claude Code check = %+v, want a failure for invalid JSON", c)
+	}
+	if Worst(checks) != StatusFail {
+		t.Errorf("Worst() = %s, want fail", Worst(checks))
+	}
+}
@@ -0,0 +1,25 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// agentsExporter concatenates the skills into a single AGENTS.md, one
+// second-level section per skill.
+type agentsExporter struct{}
+
+func (agentsExporter) Format() string { return "agents" }
+
+func (agentsExporter) Export(c Catalog) ([]File, error) {
+	var sb strings.Builder
+	sb.WriteString("# Skills\n\n")
+	sb.WriteString(generatedNotice + "\n")
+	for _, s := range c.Skills {
+		fmt.Fprintf(&sb, "\n## %s\n\n", s.QualifiedName())
+		fmt.Fprintf(&sb, "> %s\n\n", strings.Join(strings.Fields(s.Description), " "))
+		sb.WriteString(demoteHeadings(s, 2))
+		sb.WriteString("\n")
+	}
+	return []File{{Path: "AGENTS.md", Data: []byte(sb.String())}}, nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+// cursorExporter writes one Cursor rule per skill to .cursor/rules. Rules
+// are agent-requested: Cursor reads the description and pulls the rule in
+// when it applies.
+type cursorExporter struct{}
+
+// cursorFrontmatter is the frontmatter of a Cursor .mdc rule.
+type cursorFrontmatter struct {
+	Description string `yaml:"description"`
+	AlwaysApply bool   `yaml:"alwaysApply"`
+}
+
+func (cursorExporter) Format() string { return "cursor" }
+
+func (cursorExporter) Export(c Catalog) ([]File, error) {
+	var files []File
+	for _, s := range c.Skills {
+		data, err := cursorRule(s)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", s.QualifiedName(), err)
+		}
+		files = append(files, File{
+			Path: ".cursor/rules/" + s.Tool + ".mdc",
+			Data: data,
+		})
+	}
+	return files, nil
+}
+
+// cursorRule renders a skill as a Cursor .mdc rule.
+func cursorRule(s *skill.Skill) ([]byte, error) {
+	front, err := yaml.Marshal(cursorFrontmatter{Description: s.Description})
+	if err != nil {
+		return nil, fmt.Errorf("encode frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(front)
+	buf.WriteString("---\n\n")
+	buf.WriteString(generatedNotice + "\n\n")
+	buf.WriteString(s.Instructions)
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+// Package export renders a skill catalog in other agents' formats, so the
+// skills root can drive agents that do not speak MCP.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// ErrUnknownFormat is returned when an export format name is not recognized.
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Catalog is the set of skills to export.
+type Catalog struct {
+	// Root is the skills root the skills were scanned from.
+	Root string
+
+	// Skills are the skills to export, in the order they are listed.
+	Skills []*skill.Skill
+}
+
+// File is an exported file.
+type File struct {
+	// Path is the file's slash-separated path relative to the output
+	// directory.
+	Path string
+
+	// Data is the file's content.
+	Data []byte
+}
+
+// Exporter renders a catalog in one format.
+type Exporter interface {
+	// Format returns the exporter's name, e.g. "cursor".
+	Format() string
+
+	// Export renders the catalog as one or more files.
+	Export(c Catalog) ([]File, error)
+}
+
+// Exporters returns all exporters.
+func Exporters() []Exporter {
+	return []Exporter{
+		cursorExporter{},
+		agentsExporter{},
+		openAIExporter{},
+		llmsTxtExporter{},
+	}
+}
+
+// Formats returns the names of all export formats.
+func Formats() []string {
+	var names []string
+	for _, e := range Exporters() {
+		names = append(names, e.Format())
+	}
+	return names
+}
+
+// Lookup returns the exporter for the named format.
+func Lookup(format string) (Exporter, error) {
+	for _, e := range Exporters() {
+		if e.Format() == format {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q (want one of %s)", ErrUnknownFormat, format, strings.Join(Formats(), ", "))
+}
+
+// generatedNotice marks exported files as generated, in an HTML comment so
+// it is hidden when the markdown is rendered.
+const generatedNotice = "<!-- Generated by skills export; edit the skills instead. -->"
+
+// demoteHeadings returns the skill's instructions with every heading moved
+// down by levels, stopping at level 6, so they nest under a heading of the
+// exporter's own.
+func demoteHeadings(s *skill.Skill, levels int) string {
+	text := s.Instructions
+	for i := len(s.Sections) - 1; i >= 0; i-- {
+		sec := s.Sections[i]
+		n := min(levels, 6-sec.Level)
+		if n <= 0 {
+			continue
+		}
+		at := sec.Start + len(text[sec.Start:]) - len(strings.TrimLeft(text[sec.Start:], " "))
+		text = text[:at] + strings.Repeat("#", n) + text[at:]
+	}
+	return text
+}
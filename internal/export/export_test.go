@@ -0,0 +1,151 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func testCatalog() Catalog {
+	root := "/skills"
+	review := &skill.Skill{
+		Name:         "review",
+		Description:  "Review code\nthoroughly",
+		Instructions: "# Review\n\nBe kind.\n\n###### Deep\n\nText.",
+		Tool:         "review",
+		File:         filepath.Join(root, "review", "SKILL.md"),
+	}
+	goTests := &skill.Skill{
+		Name:         "testing",
+		Namespace:    "backend",
+		Description:  "Write tests",
+		Instructions: "Run go test.",
+		Tool:         "backend__testing",
+		File:         filepath.Join(root, "backend", "testing.skill.md"),
+	}
+	for _, s := range []*skill.Skill{review, goTests} {
+		s.Sections = registry.ParseSections(s.Instructions)
+	}
+	return Catalog{Root: root, Skills: []*skill.Skill{review, goTests}}
+}
+
+func exportFiles(t *testing.T, format string) []File {
+	t.Helper()
+	e, err := Lookup(format)
+	if err != nil {
+		t.Fatalf("Lookup(%s) error: %v", format, err)
+	}
+	files, err := e.Export(testCatalog())
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	return files
+}
+
+func TestCursor(t *testing.T) {
+	files := exportFiles(t, "cursor")
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+	if files[1].Path != ".cursor/rules/backend__testing.mdc" {
+		t.Errorf("Path = %s", files[1].Path)
+	}
+	want := "---\ndescription: Write tests\nalwaysApply: false\n---\n\n" + generatedNotice + "\n\nRun go test.\n"
+	if got := string(files[1].Data); got != want {
+		t.Errorf("rule =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAgents(t *testing.T) {
+	files := exportFiles(t, "agents")
+	want := "# Skills\n\n" + generatedNotice + "\n" +
+		"\n## review\n\n> Review code thoroughly\n\n### Review\n\nBe kind.\n\n###### Deep\n\nText.\n" +
+		"\n## backend.testing\n\n> Write tests\n\nRun go test.\n"
+	if len(files) != 1 || files[0].Path != "AGENTS.md" {
+		t.Fatalf("files = %+v, want AGENTS.md", files)
+	}
+	if got := string(files[0].Data); got != want {
+		t.Errorf("AGENTS.md =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestOpenAI(t *testing.T) {
+	files := exportFiles(t, "openai")
+	var tools []openAITool
+	if err := json.Unmarshal(files[0].Data, &tools); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(tools) != 2 || tools[1].Type != "function" || tools[1].Function.Name != "backend__testing" {
+		t.Errorf("tools = %+v", tools)
+	}
+	if tools[0].Function.Parameters["type"] != "object" {
+		t.Errorf("parameters = %v", tools[0].Function.Parameters)
+	}
+}
+
+func TestOpenAIFunctionNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		tools   []string
+		want    string
+		wantErr bool
+	}{
+		{name: "dots", tools: []string{"skills.backend.review"}, want: "skills_backend_review"},
+		{name: "too long", tools: []string{strings.Repeat("a", 65)}, wantErr: true},
+		{name: "collision", tools: []string{"a.b", "a_b"}, wantErr: true},
+	}
+
+	e, err := Lookup("openai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Catalog
+			for i, tool := range tt.tools {
+				c.Skills = append(c.Skills, &skill.Skill{Name: "skill" + strings.Repeat("x", i), Description: "D", Tool: tool})
+			}
+			files, err := e.Export(c)
+			if tt.wantErr {
+				if !errors.Is(err, ErrFunctionName) || !strings.Contains(err.Error(), `skill "skill`) {
+					t.Errorf("Export() error = %v, want %v naming the skill", err, ErrFunctionName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Export() error: %v", err)
+			}
+			var tools []openAITool
+			if err := json.Unmarshal(files[0].Data, &tools); err != nil {
+				t.Fatal(err)
+			}
+			if tools[0].Function.Name != tt.want {
+				t.Errorf("Name = %q, want %q", tools[0].Function.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestLLMsTxt(t *testing.T) {
+	files := exportFiles(t, "llms-txt")
+	got := string(files[0].Data)
+	for _, want := range []string{
+		"\n## Skills\n\n- [review](review/SKILL.md): Review code thoroughly\n",
+		"\n## backend\n\n- [testing](backend/testing.skill.md): Write tests\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("llms.txt missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("windsurf"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Lookup(windsurf) error = %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// llmsTxtExporter writes an llms.txt index of the skills, linking each to
+// its markdown file relative to the skills root and grouped by namespace.
+type llmsTxtExporter struct{}
+
+func (llmsTxtExporter) Format() string { return "llms-txt" }
+
+func (llmsTxtExporter) Export(c Catalog) ([]File, error) {
+	var sb strings.Builder
+	sb.WriteString("# Skills\n\n")
+	sb.WriteString("> Expert instructions for specific tasks. Read a skill's file before doing the task it describes.\n")
+
+	for i, s := range c.Skills {
+		if i == 0 || s.Namespace != c.Skills[i-1].Namespace {
+			heading := "Skills"
+			if s.Namespace != "" {
+				heading = s.Namespace
+			}
+			fmt.Fprintf(&sb, "\n## %s\n\n", heading)
+		}
+
+		link, err := filepath.Rel(c.Root, s.File)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", s.QualifiedName(), err)
+		}
+		description := strings.Join(strings.Fields(s.Description), " ")
+		fmt.Fprintf(&sb, "- [%s](%s): %s\n", s.Name, filepath.ToSlash(link), description)
+	}
+	return []File{{Path: "llms.txt", Data: []byte(sb.String())}}, nil
+}
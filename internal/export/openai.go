@@ -0,0 +1,95 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxFunctionNameLength is the longest function name OpenAI accepts.
+const maxFunctionNameLength = 64
+
+// ErrFunctionName is returned when a skill's tool name cannot be mapped to
+// an OpenAI function name.
+var ErrFunctionName = errors.New("tool name cannot be an OpenAI function name")
+
+// openAIExporter writes the skills as OpenAI function tool definitions,
+// one per skill, with the same arguments as the MCP skill tools.
+type openAIExporter struct{}
+
+// openAITool is an OpenAI function tool definition.
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIFunction `json:"function"`
+}
+
+type openAIFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// openAIParameters is the JSON Schema of a skill tool's arguments.
+var openAIParameters = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"section": map[string]any{
+			"type":        "string",
+			"description": "Heading slug or title of a single section to read. Omit to read the skill.",
+		},
+		"locale": map[string]any{
+			"type":        "string",
+			"description": "Language tag of the localized variant to read, e.g. ja or pt-BR.",
+		},
+	},
+	"additionalProperties": false,
+}
+
+func (openAIExporter) Format() string { return "openai" }
+
+func (openAIExporter) Export(c Catalog) ([]File, error) {
+	tools := make([]openAITool, 0, len(c.Skills))
+	names := make(map[string]string, len(c.Skills))
+	for _, s := range c.Skills {
+		name, err := functionName(s.Tool)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", s.QualifiedName(), err)
+		}
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("skill %q: %w: %s is also the function name of skill %q", s.QualifiedName(), ErrFunctionName, name, other)
+		}
+		names[name] = s.QualifiedName()
+
+		tools = append(tools, openAITool{
+			Type: "function",
+			Function: openAIFunction{
+				Name:        name,
+				Description: s.Description,
+				Parameters:  openAIParameters,
+			},
+		})
+	}
+
+	data, err := json.MarshalIndent(tools, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode tools: %w", err)
+	}
+	return []File{{Path: "tools.json", Data: append(data, '\n')}}, nil
+}
+
+// functionName maps an MCP tool name to an OpenAI function name, which
+// must match ^[a-zA-Z0-9_-]{1,64}$. Dots, which MCP allows, become
+// underscores.
+func functionName(tool string) (string, error) {
+	name := strings.ReplaceAll(tool, ".", "_")
+	if name == "" || len(name) > maxFunctionNameLength {
+		return "", fmt.Errorf("%w: %q must be 1 to %d characters", ErrFunctionName, tool, maxFunctionNameLength)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return "", fmt.Errorf("%w: %q contains %q", ErrFunctionName, tool, r)
+		}
+	}
+	return name, nil
+}
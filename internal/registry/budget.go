@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+var (
+	// ErrSkillOverBudget is reported when a skill exceeds TokenBudget.PerSkill.
+	ErrSkillOverBudget = errors.New("skill exceeds per-skill token budget")
+	// ErrCatalogOverBudget is reported when the catalog exceeds TokenBudget.Total.
+	ErrCatalogOverBudget = errors.New("skill catalog exceeds total token budget")
+)
+
+// TokenBudget limits how many model tokens skills may consume.
+// Zero limits are unlimited.
+type TokenBudget struct {
+	// PerSkill is the maximum number of tokens a single skill may use.
+	PerSkill int
+
+	// Total is the maximum number of tokens across the whole catalog.
+	Total int
+
+	// Reject drops skills that exceed the budget instead of only warning.
+	Reject bool
+}
+
+// checkSkill reports whether s fits within the per-skill budget.
+func (b TokenBudget) checkSkill(s *skill.Skill) error {
+	if b.PerSkill > 0 && s.Tokens > b.PerSkill {
+		return fmt.Errorf("%w: %d tokens (max %d)", ErrSkillOverBudget, s.Tokens, b.PerSkill)
+	}
+	return nil
+}
+
+// checkTotal returns the catalog's total tokens and the names of skills that
+// push it past the total budget. Skills are visited in name order so the
+// same skills are chosen on every scan.
+func (b TokenBudget) checkTotal(skills map[string]*skill.Skill) (total int, over []string) {
+	names := make([]string, 0, len(skills))
+	for name := range skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var kept int
+	for _, name := range names {
+		n := skills[name].Tokens
+		total += n
+		if b.Total > 0 && kept+n > b.Total {
+			over = append(over, name)
+			continue
+		}
+		kept += n
+	}
+	return total, over
+}
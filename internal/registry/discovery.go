@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Symlink policies for discovery.
+const (
+	// SymlinksIgnore skips symlinked files and directories.
+	SymlinksIgnore = "ignore"
+	// SymlinksWithinRoot follows symlinks whose target lies within the
+	// skills root. It is the default.
+	SymlinksWithinRoot = "within-root"
+	// SymlinksFollow follows symlinks wherever they point.
+	SymlinksFollow = "follow"
+)
+
+var (
+	// ErrUnknownSymlinkPolicy is returned for an unrecognized symlink policy.
+	ErrUnknownSymlinkPolicy = errors.New("unknown symlink policy")
+
+	// ErrSymlinkOutsideRoot is reported for a symlink skipped under the
+	// within-root policy because its target lies outside the skills root.
+	ErrSymlinkOutsideRoot = errors.New("symlink target outside the skills root")
+)
+
+// TemplatesDir is the directory directly under the skills root that holds
+// templates for new skills. It is never searched, even with IncludeHidden,
+// so templates are not served as skills.
+const TemplatesDir = ".templates"
+
+// vcsDirs are version control directories that are never searched for skills.
+var vcsDirs = map[string]bool{
+	".git":    true,
+	".hg":     true,
+	".svn":    true,
+	".bzr":    true,
+	"_darcs":  true,
+	"CVS":     true,
+	".jj":     true,
+	".fossil": true,
+}
+
+// Discovery controls which parts of the skills root a scan searches.
+// Directories matched by .skillsignore files, in gitignore syntax, are
+// always skipped.
+type Discovery struct {
+	// MaxDepth limits how many directories below the root are searched.
+	// 1 searches only the root's immediate subdirectories. Zero means no limit.
+	MaxDepth int
+
+	// IncludeHidden searches directories whose names start with a dot.
+	// Version control directories and the root's TemplatesDir are never
+	// searched.
+	IncludeHidden bool
+
+	// Symlinks is the symlink policy: SymlinksIgnore, SymlinksWithinRoot
+	// (the default when empty) or SymlinksFollow. Directories reached more
+	// than once, e.g. through a symlink loop, are searched only once.
+	Symlinks string
+}
+
+// Validate reports whether the discovery settings are usable.
+func (d Discovery) Validate() error {
+	switch d.Symlinks {
+	case "", SymlinksIgnore, SymlinksWithinRoot, SymlinksFollow:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownSymlinkPolicy, d.Symlinks)
+	}
+	if d.MaxDepth < 0 {
+		return fmt.Errorf("max depth must not be negative: %d", d.MaxDepth)
+	}
+	return nil
+}
+
+// excludesDir reports whether a directory named name, in a directory
+// depth levels below the root, is skipped by default.
+func (d Discovery) excludesDir(name string, depth int) bool {
+	if vcsDirs[name] || depth == 0 && name == TemplatesDir {
+		return true
+	}
+	return !d.IncludeHidden && strings.HasPrefix(name, ".")
+}
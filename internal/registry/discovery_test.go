@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeNamedSkill(t *testing.T, dir, name string) {
+	t.Helper()
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: "+name+"\ndescription: The "+name+" skill\n---\n\nInstructions.\n")
+}
+
+func skillNames(reg *Registry) string {
+	var names []string
+	for _, s := range reg.List() {
+		names = append(names, s.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func TestRegistryDiscovery(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeNamedSkill(t, filepath.Join(tmpDir, "top"), "top")
+	writeNamedSkill(t, filepath.Join(tmpDir, "a", "b", "deep"), "deep")
+	writeNamedSkill(t, filepath.Join(tmpDir, ".hidden"), "hidden")
+	writeNamedSkill(t, filepath.Join(tmpDir, ".git", "stray"), "vcs")
+	writeNamedSkill(t, filepath.Join(tmpDir, ".templates", "basic"), "template")
+	writeNamedSkill(t, filepath.Join(tmpDir, "node_modules", "pkg"), "dependency")
+	writeNamedSkill(t, filepath.Join(tmpDir, "drafts", "wip"), "wip")
+	writeNamedSkill(t, filepath.Join(tmpDir, "drafts", "ready"), "ready")
+	writeFile(t, filepath.Join(tmpDir, ".skillsignore"), "# Not skills\nnode_modules/\n/drafts/*\n!/drafts/ready\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name      string
+		discovery Discovery
+		want      string
+	}{
+		{name: "defaults", want: "deep,ready,top"},
+		{name: "max depth", discovery: Discovery{MaxDepth: 2}, want: "ready,top"},
+		{name: "include hidden", discovery: Discovery{IncludeHidden: true}, want: "deep,hidden,ready,top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := NewRegistry(tmpDir, logger, WithDiscovery(tt.discovery))
+			if err := reg.Scan(); err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+			if got := skillNames(reg); got != tt.want {
+				t.Errorf("skills = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrySymlinks(t *testing.T) {
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "skills")
+	outside := filepath.Join(tmpDir, "outside")
+
+	writeNamedSkill(t, filepath.Join(root, "local"), "local")
+	writeNamedSkill(t, filepath.Join(root, "shared", "common"), "common")
+	writeNamedSkill(t, filepath.Join(outside, "external"), "external")
+
+	links := map[string]string{
+		filepath.Join(root, "linked-outside"):   outside,
+		filepath.Join(root, "alias"):            filepath.Join(root, "shared"),
+		filepath.Join(root, "local", "loop"):    root,
+		filepath.Join(root, "shared", "parent"): filepath.Join(root, "shared"),
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		policy string
+		want   string
+	}{
+		{policy: "", want: "common,local"},
+		{policy: SymlinksIgnore, want: "common,local"},
+		{policy: SymlinksWithinRoot, want: "common,local"},
+		{policy: SymlinksFollow, want: "common,external,local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			reg := NewRegistry(root, logger, WithDiscovery(Discovery{Symlinks: tt.policy}))
+			if err := reg.Scan(); err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+			if got := skillNames(reg); got != tt.want {
+				t.Errorf("skills = %s, want %s", got, tt.want)
+			}
+			if len(reg.Conflicts()) != 0 {
+				t.Errorf("directories reached twice should be searched once, got conflicts %+v", reg.Conflicts())
+			}
+			if got := reg.Get("common").Path; got != filepath.Join(root, "shared", "common") {
+				t.Errorf("common Path = %s, want its own path rather than a symlinked one", got)
+			}
+		})
+	}
+}
+
+func TestDiscoveryValidate(t *testing.T) {
+	if err := (Discovery{Symlinks: "sometimes"}).Validate(); !errors.Is(err, ErrUnknownSymlinkPolicy) {
+		t.Errorf("Validate() error = %v, want ErrUnknownSymlinkPolicy", err)
+	}
+	if err := (Discovery{MaxDepth: -1}).Validate(); err == nil {
+		t.Error("Validate() should reject a negative max depth")
+	}
+	if err := (Discovery{}).Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+}
+
+func TestRegistrySingleFileSkills(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeNamedSkill(t, filepath.Join(tmpDir, "review"), "review")
+	writeFile(t, filepath.Join(tmpDir, "quick.skill.md"), "---\nname: quick\ndescription: A quick skill\n---\n\nBe quick.\n")
+	writeFile(t, filepath.Join(tmpDir, "backend", "lint.skill.md"), "---\nname: lint\ndescription: Lint backend code\n---\n\nRun the linter.\n")
+	writeFile(t, filepath.Join(tmpDir, "backend", "review.skill.md"), "---\nname: review\ndescription: Backend review\n---\n\nReview.\n")
+	writeFile(t, filepath.Join(tmpDir, "notes.prompt.md"), "---\nname: notes\ndescription: Take notes\n---\n\nWrite it down.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	t.Run("default pattern", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger)
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if got := skillNames(reg); got != "lint,quick,review" {
+			t.Errorf("skills = %s, want lint,quick,review", got)
+		}
+		quick := reg.Get("quick")
+		if quick.File != filepath.Join(tmpDir, "quick.skill.md") || quick.Path != tmpDir {
+			t.Errorf("quick File = %s, Path = %s", quick.File, quick.Path)
+		}
+
+		conflicts := reg.Conflicts()
+		if len(conflicts) != 1 || conflicts[0].Key != "review" {
+			t.Fatalf("Conflicts() = %+v, want one conflict over review", conflicts)
+		}
+		if got := conflicts[0].Shadowed()[0].File; got != filepath.Join(tmpDir, "review", "SKILL.md") {
+			t.Errorf("shadowed = %s, want the later-sorted review/SKILL.md", got)
+		}
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger, WithNamespaces(true))
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if reg.Get("backend.lint") == nil || reg.Get("backend.review") == nil || reg.Get("review") == nil {
+			t.Errorf("single-file skills should be namespaced by their directory, got %s", skillNames(reg))
+		}
+		if len(reg.Conflicts()) != 0 {
+			t.Errorf("Conflicts() = %+v, want none", reg.Conflicts())
+		}
+	})
+
+	t.Run("custom pattern", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger, WithSkillFilePattern("*.prompt.md"))
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if got := skillNames(reg); got != "notes,review" {
+			t.Errorf("skills = %s, want notes,review", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger, WithSkillFilePattern(""))
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if got := skillNames(reg); got != "review" {
+			t.Errorf("skills = %s, want review", got)
+		}
+	})
+}
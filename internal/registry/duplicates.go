@@ -0,0 +1,246 @@
+package registry
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// Duplicate policies decide which of several skills sharing a name or tool
+// name is registered. Candidates are considered in discovery order, which
+// is the lexical order of their file paths.
+const (
+	// DuplicateFirst registers the first candidate. It is the default.
+	DuplicateFirst = "first"
+	// DuplicateLast registers the last candidate.
+	DuplicateLast = "last"
+	// DuplicateError fails the scan.
+	DuplicateError = "error"
+	// DuplicateHighestVersion registers the candidate with the highest
+	// version frontmatter field, preferring the first on a tie.
+	DuplicateHighestVersion = "highest-version"
+	// DuplicatePriority registers the candidate with the highest priority
+	// frontmatter field, preferring the first on a tie.
+	DuplicatePriority = "explicit-priority"
+)
+
+// Conflict kinds.
+const (
+	// ConflictName is a conflict between skills with the same qualified name.
+	ConflictName = "name"
+	// ConflictToolName is a conflict between skills whose names normalize to
+	// the same tool name.
+	ConflictToolName = "tool-name"
+)
+
+var (
+	// ErrDuplicateSkill is returned by Scan under DuplicateError when skills
+	// share a name or tool name.
+	ErrDuplicateSkill = errors.New("duplicate skill")
+	// ErrUnknownDuplicatePolicy is returned for an unrecognized duplicate policy.
+	ErrUnknownDuplicatePolicy = errors.New("unknown duplicate policy")
+)
+
+// Conflict records skills that competed for the same name or tool name and
+// how the conflict was settled.
+type Conflict struct {
+	// Kind is ConflictName or ConflictToolName.
+	Kind string
+
+	// Key is the contested qualified name or tool name.
+	Key string
+
+	// Policy is the duplicate policy that settled the conflict.
+	Policy string
+
+	// Candidates are all competing skills in discovery order.
+	Candidates []*skill.Skill
+
+	// Winner is the registered candidate, or nil under DuplicateError.
+	Winner *skill.Skill
+
+	// Reason explains why Winner was chosen.
+	Reason string
+}
+
+// Shadowed returns the candidates that were not registered.
+func (c Conflict) Shadowed() []*skill.Skill {
+	var shadowed []*skill.Skill
+	for _, s := range c.Candidates {
+		if s != c.Winner {
+			shadowed = append(shadowed, s)
+		}
+	}
+	return shadowed
+}
+
+// ValidateDuplicatePolicy reports whether policy is a known duplicate policy.
+// The empty string selects DuplicateFirst.
+func ValidateDuplicatePolicy(policy string) error {
+	switch policy {
+	case "", DuplicateFirst, DuplicateLast, DuplicateError, DuplicateHighestVersion, DuplicatePriority:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownDuplicatePolicy, policy)
+}
+
+// dedupe settles name and then tool name conflicts among candidates, given
+// in discovery order. It returns the winners keyed by qualified name, their
+// tool names, and every conflict. Under DuplicateError it returns an error
+// describing the conflicts, and the winners are incomplete.
+func (r *Registry) dedupe(candidates []*skill.Skill) (map[string]*skill.Skill, map[string]string, []Conflict, error) {
+	var conflicts []Conflict
+
+	byName, nameOrder := group(candidates, (*skill.Skill).QualifiedName)
+	var named []*skill.Skill
+	for _, key := range nameOrder {
+		winner, conflict := r.settle(ConflictName, key, byName[key])
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+		if winner != nil {
+			named = append(named, winner)
+		}
+	}
+
+	skills := make(map[string]*skill.Skill, len(named))
+	toolNames := make(map[string]string, len(named))
+	byTool, toolOrder := group(named, func(s *skill.Skill) string { return s.Tool })
+	for _, tool := range toolOrder {
+		winner, conflict := r.settle(ConflictToolName, tool, byTool[tool])
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+		if winner != nil {
+			skills[winner.QualifiedName()] = winner
+			toolNames[tool] = winner.QualifiedName()
+		}
+	}
+
+	if r.duplicates == DuplicateError && len(conflicts) > 0 {
+		msgs := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			files := make([]string, len(c.Candidates))
+			for j, s := range c.Candidates {
+				files[j] = s.File
+			}
+			msgs[i] = fmt.Sprintf("%s %q: %s", c.Kind, c.Key, strings.Join(files, ", "))
+		}
+		return nil, nil, conflicts, fmt.Errorf("%w: %s", ErrDuplicateSkill, strings.Join(msgs, "; "))
+	}
+	return skills, toolNames, conflicts, nil
+}
+
+// settle picks the winner among candidates sharing key. A lone candidate
+// wins without a conflict.
+func (r *Registry) settle(kind, key string, candidates []*skill.Skill) (*skill.Skill, *Conflict) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	policy := r.duplicates
+	if policy == "" {
+		policy = DuplicateFirst
+	}
+	c := &Conflict{Kind: kind, Key: key, Policy: policy, Candidates: candidates}
+
+	switch policy {
+	case DuplicateFirst:
+		c.Winner = candidates[0]
+		c.Reason = "first discovered"
+	case DuplicateLast:
+		c.Winner = candidates[len(candidates)-1]
+		c.Reason = "last discovered"
+	case DuplicateHighestVersion:
+		c.Winner = candidates[0]
+		for _, s := range candidates[1:] {
+			if compareVersions(s.Version, c.Winner.Version) > 0 {
+				c.Winner = s
+			}
+		}
+		c.Reason = fmt.Sprintf("highest version %q", c.Winner.Version)
+	case DuplicatePriority:
+		c.Winner = candidates[0]
+		for _, s := range candidates[1:] {
+			if s.Priority > c.Winner.Priority {
+				c.Winner = s
+			}
+		}
+		c.Reason = fmt.Sprintf("highest priority %d", c.Winner.Priority)
+	case DuplicateError:
+		c.Reason = "duplicates are errors"
+	}
+
+	shadowed := make([]string, 0, len(candidates)-1)
+	for _, s := range c.Shadowed() {
+		shadowed = append(shadowed, s.File)
+	}
+	winner := ""
+	if c.Winner != nil {
+		winner = c.Winner.File
+	}
+	r.logger.Warn("duplicate skill",
+		"kind", kind,
+		"key", key,
+		"policy", policy,
+		"registered", winner,
+		"shadowed", shadowed,
+		"reason", c.Reason,
+	)
+	return c.Winner, c
+}
+
+// group groups skills by key, preserving the order in which keys first
+// appear and the order of skills within each group.
+func group(skills []*skill.Skill, key func(*skill.Skill) string) (map[string][]*skill.Skill, []string) {
+	groups := make(map[string][]*skill.Skill)
+	var order []string
+	for _, s := range skills {
+		k := key(s)
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], s)
+	}
+	return groups, order
+}
+
+// compareVersions compares dotted versions such as "1.2.0" or "v2",
+// numerically where both components are numbers and lexically otherwise.
+// A missing component or version sorts lowest. It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		if i >= len(as) {
+			return -1
+		}
+		if i >= len(bs) {
+			return 1
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmp.Compare(an, bn)
+			}
+		case as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return 0
+}
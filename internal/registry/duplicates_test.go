@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"v2", "1.9", 1},
+		{"1.0", "1.0.1", -1},
+		{"", "0.1", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := compareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryDuplicatePolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "a", "SKILL.md"), "---\nname: review\ndescription: A\nversion: 1.2\npriority: 5\n---\n\nA.\n")
+	writeFile(t, filepath.Join(tmpDir, "b", "SKILL.md"), "---\nname: review\ndescription: B\nversion: 1.10.0\n---\n\nB.\n")
+	writeFile(t, filepath.Join(tmpDir, "c", "SKILL.md"), "---\nname: review\ndescription: C\npriority: 10\n---\n\nC.\n")
+	writeFile(t, filepath.Join(tmpDir, "d", "SKILL.md"), "---\nname: git-flow\ndescription: D\n---\n\nD.\n")
+	writeFile(t, filepath.Join(tmpDir, "e", "SKILL.md"), "---\nname: git_flow\ndescription: E\npriority: 1\n---\n\nE.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		policy     string
+		wantReview string
+		wantFlow   string
+	}{
+		{policy: "", wantReview: "A", wantFlow: "git-flow"},
+		{policy: DuplicateFirst, wantReview: "A", wantFlow: "git-flow"},
+		{policy: DuplicateLast, wantReview: "C", wantFlow: "git_flow"},
+		{policy: DuplicateHighestVersion, wantReview: "B", wantFlow: "git-flow"},
+		{policy: DuplicatePriority, wantReview: "C", wantFlow: "git_flow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			reg := NewRegistry(tmpDir, logger, WithDuplicatePolicy(tt.policy))
+			if err := reg.Scan(); err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+
+			if got := reg.Get("review"); got == nil || got.Description != tt.wantReview {
+				t.Errorf("Get(review) = %v, want description %s", got, tt.wantReview)
+			}
+			if reg.Get(tt.wantFlow) == nil || reg.Count() != 2 {
+				t.Errorf("want %s registered alongside review, got %d skills", tt.wantFlow, reg.Count())
+			}
+
+			conflicts := reg.Conflicts()
+			if len(conflicts) != 2 {
+				t.Fatalf("Conflicts() returned %d, want 2", len(conflicts))
+			}
+			if c := conflicts[0]; c.Kind != ConflictName || c.Key != "review" || len(c.Candidates) != 3 || len(c.Shadowed()) != 2 {
+				t.Errorf("name conflict = %+v", c)
+			}
+			if c := conflicts[1]; c.Kind != ConflictToolName || c.Key != "git_flow" || len(c.Shadowed()) != 1 {
+				t.Errorf("tool name conflict = %+v", c)
+			}
+		})
+	}
+
+	t.Run(DuplicateError, func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger, WithDuplicatePolicy(DuplicateError))
+		err := reg.Scan()
+		if !errors.Is(err, ErrDuplicateSkill) {
+			t.Fatalf("Scan() error = %v, want ErrDuplicateSkill", err)
+		}
+		if reg.Count() != 0 || reg.Status().Ready() {
+			t.Error("a failed scan should register nothing and not be ready")
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// Section merge modes a child skill may declare for a parent's sections.
+const (
+	SectionReplace = "replace"
+	SectionAppend  = "append"
+	SectionPrepend = "prepend"
+)
+
+var (
+	// ErrInvalidSectionMode is returned when a section mode is not replace, append or prepend.
+	ErrInvalidSectionMode = errors.New("invalid section mode")
+	// ErrSectionConflict is returned when a child edits overlapping parent sections.
+	ErrSectionConflict = errors.New("conflicting section edits")
+)
+
+// validSectionMode reports whether mode is a known section merge mode.
+func validSectionMode(mode string) bool {
+	switch mode {
+	case SectionReplace, SectionAppend, SectionPrepend:
+		return true
+	}
+	return false
+}
+
+// edit replaces parent[start:end] with the markdown block text; start == end
+// is an insertion.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// mergeInstructions applies a child skill's markdown onto its parent's.
+//
+// The child is split into blocks at its top-level headings. A block whose
+// heading matches a parent section by title or slug replaces that section by
+// default, or is appended or prepended to the section's content according
+// to modes, which is keyed by heading title or slug. Blocks matching no
+// parent section are appended to the end, and the child's text before its
+// first heading is appended to the parent's overview.
+func mergeInstructions(parent, child string, modes map[string]string) (string, error) {
+	parentSecs := ParseSections(parent)
+	childSecs := ParseSections(child)
+
+	minLevel := 7
+	for _, sec := range childSecs {
+		minLevel = min(minLevel, sec.Level)
+	}
+	var blocks []skill.Section
+	for _, sec := range childSecs {
+		if sec.Level == minLevel {
+			blocks = append(blocks, sec)
+		}
+	}
+
+	preambleEnd := len(child)
+	if len(blocks) > 0 {
+		preambleEnd = blocks[0].Start
+	}
+
+	var edits []edit
+	if preamble := strings.TrimSpace(child[:preambleEnd]); preamble != "" {
+		edits = append(edits, edit{
+			start: overviewEnd(parent, parentSecs),
+			end:   overviewEnd(parent, parentSecs),
+			text:  preamble,
+		})
+	}
+
+	var appended []string
+	for _, block := range blocks {
+		content := block.Content(child)
+		target, ok := findSection(parentSecs, block)
+		if !ok {
+			appended = append(appended, content)
+			continue
+		}
+
+		mode := sectionMode(modes, block)
+		_, body, _ := strings.Cut(content, "\n")
+		body = strings.TrimSpace(body)
+
+		switch mode {
+		case SectionReplace:
+			edits = append(edits, edit{start: target.Start, end: target.End, text: content})
+		case SectionAppend:
+			edits = append(edits, edit{start: target.End, end: target.End, text: body})
+		case SectionPrepend:
+			headingEnd := target.Start + strings.Index(parent[target.Start:], "\n") + 1
+			if headingEnd <= target.Start {
+				headingEnd = target.End
+			}
+			edits = append(edits, edit{start: headingEnd, end: headingEnd, text: body})
+		default:
+			return "", fmt.Errorf("%w: %q for section %q", ErrInvalidSectionMode, mode, block.Title)
+		}
+	}
+
+	// Order by offset, with insertions ahead of a replacement starting at the
+	// same offset so they land before the replaced section.
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start < edits[j].start
+		}
+		return edits[i].start == edits[i].end && edits[j].start != edits[j].end
+	})
+	for i := 1; i < len(edits); i++ {
+		if edits[i].start < edits[i-1].end {
+			return "", fmt.Errorf("%w: overlapping edits at offset %d", ErrSectionConflict, edits[i].start)
+		}
+	}
+
+	merged := parent
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		merged = joinBlocks(merged[:e.start], e.text, merged[e.end:])
+	}
+	for _, content := range appended {
+		merged = joinBlocks(merged, content)
+	}
+
+	return strings.TrimSpace(merged), nil
+}
+
+// joinBlocks joins markdown fragments with exactly one blank line between them.
+func joinBlocks(parts ...string) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		part = strings.Trim(part, "\n")
+		if part == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// overviewEnd returns the offset where the parent's overview ends: the start
+// of the first section after a leading top-level title.
+func overviewEnd(markdown string, sections []skill.Section) int {
+	for _, sec := range sections {
+		if sec.Start == 0 && sec.Level == 1 {
+			continue
+		}
+		return sec.Start
+	}
+	return len(markdown)
+}
+
+// findSection returns the parent section a child block overrides.
+func findSection(sections []skill.Section, block skill.Section) (skill.Section, bool) {
+	for _, sec := range sections {
+		if strings.EqualFold(sec.Title, block.Title) || sec.Slug == block.Slug {
+			return sec, true
+		}
+	}
+	return skill.Section{}, false
+}
+
+// sectionMode returns the merge mode declared for block, defaulting to
+// replace. When several keys match, the block's exact title wins, then its
+// title in any case, then its slug, so the result does not depend on map
+// order.
+func sectionMode(modes map[string]string, block skill.Section) string {
+	if mode, ok := modes[block.Title]; ok {
+		return mode
+	}
+	keys := make([]string, 0, len(modes))
+	for key := range modes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, match := range []string{block.Title, block.Slug} {
+		for _, key := range keys {
+			if strings.EqualFold(key, match) {
+				return modes[key]
+			}
+		}
+	}
+	return SectionReplace
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+const parentMarkdown = `# Review
+
+Review code carefully.
+
+## Correctness
+
+Check the logic.
+
+## Security
+
+Check for injection.
+
+## Feedback
+
+Be kind.`
+
+func TestMergeInstructions(t *testing.T) {
+	tests := []struct {
+		name  string
+		child string
+		modes map[string]string
+		want  string
+	}{
+		{
+			name:  "replace by default",
+			child: "## Security\n\nUse the security team's checklist.",
+			want:  "# Review\n\nReview code carefully.\n\n## Correctness\n\nCheck the logic.\n\n## Security\n\nUse the security team's checklist.\n\n## Feedback\n\nBe kind.",
+		},
+		{
+			name:  "append",
+			child: "## correctness\n\nRun the tests.",
+			modes: map[string]string{"Correctness": SectionAppend},
+			want:  "# Review\n\nReview code carefully.\n\n## Correctness\n\nCheck the logic.\n\nRun the tests.\n\n## Security\n\nCheck for injection.\n\n## Feedback\n\nBe kind.",
+		},
+		{
+			name:  "prepend",
+			child: "## Feedback\n\nStart with praise.",
+			modes: map[string]string{"feedback": SectionPrepend},
+			want:  "# Review\n\nReview code carefully.\n\n## Correctness\n\nCheck the logic.\n\n## Security\n\nCheck for injection.\n\n## Feedback\n\nStart with praise.\n\nBe kind.",
+		},
+		{
+			name:  "preamble and new section",
+			child: "Focus on Go code.\n\n## Style\n\nRun gofmt.",
+			want:  "# Review\n\nReview code carefully.\n\nFocus on Go code.\n\n## Correctness\n\nCheck the logic.\n\n## Security\n\nCheck for injection.\n\n## Feedback\n\nBe kind.\n\n## Style\n\nRun gofmt.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeInstructions(parentMarkdown, tt.child, tt.modes)
+			if err != nil {
+				t.Fatalf("mergeInstructions() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("mergeInstructions() =\n%s\n\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeInstructionsConflict(t *testing.T) {
+	parent := "## Setup\n\nInstall.\n\n### Details\n\nMore."
+	child := "## Setup\n\nNew setup.\n\n## Details\n\nNew details."
+
+	_, err := mergeInstructions(parent, child, nil)
+	if !errors.Is(err, ErrSectionConflict) {
+		t.Errorf("expected ErrSectionConflict, got %v", err)
+	}
+}
+
+func TestSectionMode(t *testing.T) {
+	block := skill.Section{Title: "Code Security", Slug: "code-security"}
+	tests := []struct {
+		name  string
+		modes map[string]string
+		want  string
+	}{
+		{name: "none", want: SectionReplace},
+		{name: "slug", modes: map[string]string{"code-security": SectionAppend}, want: SectionAppend},
+		{name: "title over slug", modes: map[string]string{"code-security": SectionPrepend, "code security": SectionAppend}, want: SectionAppend},
+		{name: "exact title first", modes: map[string]string{"code security": SectionPrepend, "Code Security": SectionAppend, "CODE SECURITY": SectionPrepend}, want: SectionAppend},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map iteration order varies, so repeat to catch nondeterminism.
+			for range 20 {
+				if got := sectionMode(tt.modes, block); got != tt.want {
+					t.Fatalf("sectionMode() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryExtends(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "base", "SKILL.md"), "---\nname: base\ndescription: Base review\n---\n\n"+parentMarkdown+"\n")
+	writeFile(t, filepath.Join(tmpDir, "go-review", "SKILL.md"), `---
+name: go-review
+extends: base
+sections:
+  Correctness: append
+---
+
+## Correctness
+
+Run go vet.
+`)
+	writeFile(t, filepath.Join(tmpDir, "strict-go-review", "SKILL.md"), `---
+name: strict-go-review
+description: Strict Go review
+extends: go-review
+---
+
+## Feedback
+
+Block on any issue.
+`)
+	writeFile(t, filepath.Join(tmpDir, "orphan", "SKILL.md"), "---\nname: orphan\nextends: missing\n---\n\nText.\n")
+	writeFile(t, filepath.Join(tmpDir, "loop-a", "SKILL.md"), "---\nname: loop-a\nextends: loop-b\n---\n\nA.\n")
+	writeFile(t, filepath.Join(tmpDir, "loop-b", "SKILL.md"), "---\nname: loop-b\nextends: loop-a\n---\n\nB.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	goReview := reg.Get("go-review")
+	if goReview == nil {
+		t.Fatal("Get(go-review) returned nil")
+	}
+	if goReview.Description != "Base review" {
+		t.Errorf("go-review Description = %q, want inherited %q", goReview.Description, "Base review")
+	}
+	if !strings.Contains(goReview.Instructions, "Check the logic.\n\nRun go vet.") {
+		t.Errorf("go-review Instructions missing appended section:\n%s", goReview.Instructions)
+	}
+
+	strict := reg.Get("strict-go-review")
+	if strict == nil {
+		t.Fatal("Get(strict-go-review) returned nil")
+	}
+	if got := strings.Join(strict.Lineage, ","); got != "strict-go-review,go-review,base" {
+		t.Errorf("Lineage = %s, want strict-go-review,go-review,base", got)
+	}
+	if !strings.Contains(strict.Instructions, "Run go vet.") || !strings.Contains(strict.Instructions, "Block on any issue.") {
+		t.Errorf("strict-go-review Instructions missing inherited content:\n%s", strict.Instructions)
+	}
+	if strings.Contains(strict.Instructions, "Be kind.") {
+		t.Error("strict-go-review should replace the Feedback section")
+	}
+	if len(strict.Sections) == 0 {
+		t.Error("Sections should be parsed from the merged instructions")
+	}
+
+	for _, name := range []string{"orphan", "loop-a", "loop-b"} {
+		if reg.Get(name) != nil {
+			t.Errorf("Get(%s) should be nil", name)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatSkillMD renders s as a SKILL.md file: YAML frontmatter with its
+// declared fields, followed by its markdown as written (Source), or its
+// Instructions when it has no Source. ParseSkillMD reads the result back
+// into an equivalent skill.
+func FormatSkillMD(s *skill.Skill) ([]byte, error) {
+	var fm bytes.Buffer
+	enc := yaml.NewEncoder(&fm)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return nil, fmt.Errorf("encode frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode frontmatter: %w", err)
+	}
+
+	body := s.Source
+	if body == "" {
+		body = s.Instructions
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(fm.Bytes())
+	buf.WriteString("---\n")
+	if body = strings.TrimSpace(body); body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(body)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func TestFormatSkillMD(t *testing.T) {
+	s := &skill.Skill{
+		Name:         "go-review",
+		Description:  "Review Go code: idioms & errors",
+		Extends:      "review",
+		ToolName:     "go_review",
+		Version:      "1.2.0",
+		Priority:     3,
+		SectionModes: map[string]string{"Security": SectionAppend},
+		Source:       "## Security\n\nCheck `os/exec` calls.\n\n<!-- include: shared.md -->",
+		Instructions: "resolved text that should not be written",
+	}
+
+	data, err := FormatSkillMD(s)
+	if err != nil {
+		t.Fatalf("FormatSkillMD() error: %v", err)
+	}
+
+	want := `---
+name: go-review
+description: 'Review Go code: idioms & errors'
+extends: review
+tool_name: go_review
+version: 1.2.0
+priority: 3
+sections:
+  Security: append
+---
+
+## Security
+
+Check ` + "`os/exec`" + ` calls.
+
+<!-- include: shared.md -->
+`
+	if string(data) != want {
+		t.Errorf("FormatSkillMD() =\n%s\nwant:\n%s", data, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "SKILL.md")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+	parsed, err := ParseSkillMD(path)
+	if err != nil {
+		t.Fatalf("ParseSkillMD() error: %v", err)
+	}
+	if parsed.Name != s.Name || parsed.Description != s.Description || parsed.Extends != s.Extends ||
+		parsed.ToolName != s.ToolName || parsed.Version != s.Version || parsed.Priority != s.Priority ||
+		!reflect.DeepEqual(parsed.SectionModes, s.SectionModes) || parsed.Instructions != s.Source {
+		t.Errorf("round trip = %+v, want %+v", parsed, s)
+	}
+}
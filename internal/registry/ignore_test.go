@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"drafts", "drafts", true, true},
+		{"drafts", "team/drafts", true, true},
+		{"drafts/", "drafts", false, false},
+		{"/drafts", "team/drafts", true, false},
+		{"/drafts", "drafts", true, true},
+		{"team/drafts", "team/drafts", true, true},
+		{"team/drafts", "other/team/drafts", true, false},
+		{"*.tmp", "a/b/notes.tmp", false, true},
+		{"wip-?", "wip-1", true, true},
+		{"wip-?", "wip-10", true, false},
+		{"**/legacy", "a/b/legacy", true, true},
+		{"archive/**", "archive/old/SKILL.md", false, true},
+		{"a/**/b", "a/b", true, true},
+		{"a/**/b", "a/x/y/b", true, true},
+		{"skill-[0-9]", "skill-7", true, true},
+		{"skill-[!0-9]", "skill-7", true, false},
+		{"# comment", "# comment", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			var got bool
+			if rule, ok := parseIgnoreRule(tt.pattern); ok {
+				got = !(rule.dirOnly && !tt.isDir) && rule.re.MatchString(tt.path)
+			}
+			if got != tt.want {
+				t.Errorf("pattern %q matching %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoredNegation(t *testing.T) {
+	root := &ignoreFile{dir: "/skills"}
+	for _, line := range []string{"experimental-*", "!experimental-keep"} {
+		rule, _ := parseIgnoreRule(line)
+		root.rules = append(root.rules, rule)
+	}
+	nested := &ignoreFile{dir: "/skills/team"}
+	rule, _ := parseIgnoreRule("!experimental-team")
+	nested.rules = append(nested.rules, rule)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/skills/experimental-a", true},
+		{"/skills/experimental-keep", false},
+		{"/skills/team/experimental-team", false},
+		{"/skills/team/experimental-b", true},
+		{"/skills/stable", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ignored([]*ignoreFile{root, nested}, tt.path, true); got != tt.want {
+				t.Errorf("ignored(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// MaxIncludeDepth limits how deeply include directives may nest.
+const MaxIncludeDepth = 8
+
+// includeSkillPrefix marks an include target as another skill's name
+// rather than a file path.
+const includeSkillPrefix = "skill:"
+
+var (
+	// ErrIncludeCycle is returned when include directives form a cycle.
+	ErrIncludeCycle = errors.New("include cycle")
+	// ErrIncludeDepth is returned when includes nest deeper than MaxIncludeDepth.
+	ErrIncludeDepth = errors.New("include depth exceeded")
+	// ErrIncludeOutsideSkill is returned when an include path escapes the skill directory.
+	ErrIncludeOutsideSkill = errors.New("include path escapes skill directory")
+	// ErrIncludeUnknownSkill is returned when an include names a skill that does not exist.
+	ErrIncludeUnknownSkill = errors.New("included skill not found")
+)
+
+// includeDirective matches an include directive on its own line, e.g.
+//
+//	<!-- include: shared/checklist.md -->
+//	<!-- include: skill:coding-standards -->
+var includeDirective = regexp.MustCompile(`^\s*<!--\s*include:\s*(\S+)\s*-->\s*$`)
+
+// expand replaces include directives in text, which was read from file.
+// Relative paths resolve against file's directory and must stay within the
+// owning skill's directory. It returns the expanded text, the files it pulled
+// in, and the deepest nesting reached relative to depth 0.
+func (rv *resolver) expand(owner *skill.Skill, text, file string, stack []string, depth int) (string, []string, int, error) {
+	var (
+		out      strings.Builder
+		files    []string
+		maxDepth int
+		fence    string
+	)
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			out.WriteString(line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			out.WriteString(line)
+			continue
+		}
+
+		m := includeDirective.FindStringSubmatch(line)
+		if m == nil {
+			out.WriteString(line)
+			continue
+		}
+		target := m[1]
+
+		if name, ok := strings.CutPrefix(target, includeSkillPrefix); ok {
+			key, ok := rv.lookup(owner, name)
+			if !ok {
+				return "", nil, 0, fmt.Errorf("%w: %q", ErrIncludeUnknownSkill, name)
+			}
+			if err := rv.resolve(key); err != nil {
+				return "", nil, 0, fmt.Errorf("include skill %q: %w", name, err)
+			}
+			if depth+1+rv.depth[key] > MaxIncludeDepth {
+				return "", nil, 0, fmt.Errorf("%w: including skill %q", ErrIncludeDepth, name)
+			}
+			other := rv.skills[key]
+			out.WriteString(other.Instructions)
+			out.WriteString("\n")
+			files = append(files, other.File)
+			files = append(files, other.Includes...)
+			maxDepth = max(maxDepth, 1+rv.depth[key])
+			continue
+		}
+
+		if depth+1 > MaxIncludeDepth {
+			return "", nil, 0, fmt.Errorf("%w: including %q", ErrIncludeDepth, target)
+		}
+		path, err := confine(includeDir(owner), filepath.Join(filepath.Dir(file), filepath.FromSlash(target)))
+		if err != nil {
+			return "", nil, 0, err
+		}
+		if slices.Contains(stack, path) {
+			return "", nil, 0, fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(append(stack, path), " -> "))
+		}
+		content, err := readInclude(path)
+		if err != nil {
+			return "", nil, 0, err
+		}
+
+		sub, subFiles, subDepth, err := rv.expand(owner, content, path, append(slices.Clip(stack), path), depth+1)
+		if err != nil {
+			return "", nil, 0, err
+		}
+		out.WriteString(strings.TrimRight(sub, "\n"))
+		out.WriteString("\n")
+		files = append(files, path)
+		files = append(files, subFiles...)
+		maxDepth = max(maxDepth, 1+subDepth)
+	}
+
+	return out.String(), files, maxDepth, nil
+}
+
+// includeDir returns the directory that s's file includes must stay within:
+// its skill directory or, for a single-file skill, which often sits in the
+// skills root beside other skills, a directory next to the file named after
+// it without .md, e.g. commit.skill/ for commit.skill.md.
+func includeDir(s *skill.Skill) string {
+	name := filepath.Base(s.File)
+	if _, ok := variantLocale(name); ok || name == skillFileName {
+		return s.Path
+	}
+	return filepath.Join(s.Path, strings.TrimSuffix(name, filepath.Ext(name)))
+}
+
+// confine returns path cleaned and verifies that, with symlinks resolved,
+// it lies within dir.
+func confine(dir, path string) (string, error) {
+	path = filepath.Clean(path)
+	realDir, err := filepath.EvalSymlinks(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrIncludeOutsideSkill, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve skill directory: %w", err)
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve include: %w", err)
+	}
+	rel, err := filepath.Rel(realDir, realPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrIncludeOutsideSkill, path)
+	}
+	return path, nil
+}
+
+// readInclude reads an included file, applying the same size limit as SKILL.md.
+func readInclude(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("stat include: %w", err)
+	}
+	if info.Size() > MaxSkillFileSize {
+		return "", fmt.Errorf("%w: %s is %d bytes (max %d)", ErrFileTooLarge, path, info.Size(), MaxSkillFileSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read include: %w", err)
+	}
+	return string(data), nil
+}
+
+// compactPaths removes duplicate paths, preserving first occurrence order.
+func compactPaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	out := paths[:0]
+	for _, p := range paths {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
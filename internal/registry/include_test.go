@@ -0,0 +1,208 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestRegistryIncludes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "standards", "SKILL.md"), `---
+name: standards
+description: Shared coding standards
+---
+
+Use gofmt.
+`)
+	writeFile(t, filepath.Join(tmpDir, "review", "SKILL.md"), `---
+name: review
+description: Code review
+---
+
+# Review
+
+<!-- include: shared/checklist.md -->
+
+<!-- include: skill:standards -->
+
+`+"```"+`
+<!-- include: not/expanded.md -->
+`+"```"+`
+`)
+	writeFile(t, filepath.Join(tmpDir, "review", "shared", "checklist.md"), "- Check errors\n<!-- include: nested.md -->\n")
+	writeFile(t, filepath.Join(tmpDir, "review", "shared", "nested.md"), "- Check tests\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	review := reg.Get("review")
+	if review == nil {
+		t.Fatal("Get(review) returned nil")
+	}
+
+	for _, want := range []string{"- Check errors", "- Check tests", "Use gofmt.", "<!-- include: not/expanded.md -->"} {
+		if !strings.Contains(review.Instructions, want) {
+			t.Errorf("Instructions missing %q:\n%s", want, review.Instructions)
+		}
+	}
+	if !strings.Contains(review.Source, "<!-- include: shared/checklist.md -->") {
+		t.Error("Source should keep the original directives")
+	}
+
+	wantIncludes := []string{
+		filepath.Join(tmpDir, "review", "shared", "checklist.md"),
+		filepath.Join(tmpDir, "review", "shared", "nested.md"),
+		filepath.Join(tmpDir, "standards", "SKILL.md"),
+	}
+	if strings.Join(review.Includes, ",") != strings.Join(wantIncludes, ",") {
+		t.Errorf("Includes = %v, want %v", review.Includes, wantIncludes)
+	}
+}
+
+func TestResolveIncludesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string // main's instructions; default includes a.md
+		files   map[string]string
+		wantErr error
+	}{
+		{
+			name: "file cycle",
+			files: map[string]string{
+				"a.md": "<!-- include: b.md -->\n",
+				"b.md": "<!-- include: a.md -->\n",
+			},
+			wantErr: ErrIncludeCycle,
+		},
+		{
+			name:   "skill cycle",
+			source: "<!-- include: skill:other -->\n",
+			files: map[string]string{
+				"../other/SKILL.md": "---\nname: other\ndescription: Other\n---\n\n<!-- include: skill:main -->\n",
+			},
+			wantErr: ErrIncludeCycle,
+		},
+		{
+			name:    "outside skill",
+			files:   map[string]string{"a.md": "<!-- include: ../secret.md -->\n"},
+			wantErr: ErrIncludeOutsideSkill,
+		},
+		{
+			name:    "unknown skill",
+			files:   map[string]string{"a.md": "<!-- include: skill:missing -->\n"},
+			wantErr: ErrIncludeUnknownSkill,
+		},
+		{
+			name: "too deep",
+			files: func() map[string]string {
+				files := make(map[string]string)
+				for i := 0; i <= MaxIncludeDepth; i++ {
+					files[string(rune('a'+i))+".md"] = "<!-- include: " + string(rune('a'+i+1)) + ".md -->\n"
+				}
+				files[string(rune('a'+MaxIncludeDepth+1))+".md"] = "end\n"
+				return files
+			}(),
+			wantErr: ErrIncludeDepth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeFile(t, filepath.Join(tmpDir, "secret.md"), "secret\n")
+
+			mainDir := filepath.Join(tmpDir, "main")
+			source := tt.source
+			if source == "" {
+				source = "<!-- include: a.md -->\n"
+			}
+			writeFile(t, filepath.Join(mainDir, "SKILL.md"), "---\nname: main\ndescription: Main\n---\n\n"+source)
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(mainDir, name), content)
+			}
+
+			skills := make(map[string]*skill.Skill)
+			for _, path := range []string{filepath.Join(mainDir, "SKILL.md"), filepath.Join(tmpDir, "other", "SKILL.md")} {
+				s, err := ParseSkillMD(path)
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				if err != nil {
+					t.Fatalf("ParseSkillMD(%s) error: %v", path, err)
+				}
+				s.Path = filepath.Dir(path)
+				s.File = path
+				s.Source = s.Instructions
+				skills[s.Name] = s
+			}
+
+			failed := resolveSkills(skills)
+			if !errors.Is(failed["main"], tt.wantErr) {
+				t.Errorf("resolveSkills() error = %v, want %v", failed["main"], tt.wantErr)
+			}
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+			reg := NewRegistry(tmpDir, logger)
+			if err := reg.Scan(); err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+			if reg.Get("main") != nil {
+				t.Error("skill with unresolvable includes should not be registered")
+			}
+		})
+	}
+}
+
+func TestRegistryIncludesSingleFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "private", "SKILL.md"), "---\nname: private\ndescription: Private\n---\n\nPrivate.\n")
+	writeFile(t, filepath.Join(tmpDir, "private", "notes.md"), "secret notes\n")
+	writeFile(t, filepath.Join(tmpDir, "leak.skill.md"), "---\nname: leak\ndescription: Leak\n---\n\n<!-- include: private/notes.md -->\n")
+	writeFile(t, filepath.Join(tmpDir, "quick.skill.md"), "---\nname: quick\ndescription: Quick\n---\n\n<!-- include: quick.skill/example.md -->\n")
+	writeFile(t, filepath.Join(tmpDir, "quick.skill", "example.md"), "An example.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if reg.Get("leak") != nil {
+		t.Error("single-file skill including another skill's file should not be registered")
+	}
+	quick := reg.Get("quick")
+	if quick == nil || !strings.Contains(quick.Instructions, "An example.") {
+		t.Errorf("quick = %+v, want its own directory's include", quick)
+	}
+
+	leakFile := filepath.Join(tmpDir, "leak.skill.md")
+	leak, err := ParseSkillMD(leakFile)
+	if err != nil {
+		t.Fatalf("ParseSkillMD() error: %v", err)
+	}
+	leak.Path, leak.File, leak.Source = tmpDir, leakFile, leak.Instructions
+	failed := resolveSkills(map[string]*skill.Skill{"leak": leak})
+	if !errors.Is(failed["leak"], ErrIncludeOutsideSkill) {
+		t.Errorf("resolveSkills() error = %v, want %v", failed["leak"], ErrIncludeOutsideSkill)
+	}
+}
@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+var (
+	// ErrVariantNameMismatch is returned when a localized SKILL.<lang>.md
+	// declares a different name than the skill's SKILL.md.
+	ErrVariantNameMismatch = errors.New("variant name does not match skill")
+
+	// ErrVariantWithoutSkill is reported for a SKILL.<lang>.md with no
+	// SKILL.md beside it.
+	ErrVariantWithoutSkill = errors.New("no SKILL.md for variant")
+
+	// ErrVariantInheritance is returned when a localized SKILL.<lang>.md
+	// declares extends or sections, which only the skill's SKILL.md may.
+	ErrVariantInheritance = errors.New("variant cannot declare extends or sections")
+)
+
+// variantFileName matches localized skill files such as SKILL.ja.md and
+// SKILL.pt-BR.md.
+var variantFileName = regexp.MustCompile(`^SKILL\.([A-Za-z]{2,8}(?:[-_][A-Za-z0-9]{1,8})*)\.md$`)
+
+// variantLocale returns the normalized locale of a localized skill file name.
+func variantLocale(name string) (string, bool) {
+	m := variantFileName.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return skill.NormalizeLocale(m[1]), true
+}
+
+// attachVariants groups parsed variants, keyed by directory, under the
+// registered skill in the same directory. Variants of shadowed candidates
+// are ignored; variants without a skill, with a different name or declaring
+// extends or sections are dropped with a warning. It returns the number dropped with a warning.
+func (r *Registry) attachVariants(skills map[string]*skill.Skill, variants map[string][]*skill.Skill, candidates []*skill.Skill) int {
+	byDir := make(map[string]*skill.Skill, len(candidates))
+	for _, s := range candidates {
+		if filepath.Base(s.File) == skillFileName {
+			byDir[s.Path] = s
+		}
+	}
+
+	var dropped int
+	for dir, vs := range variants {
+		for _, v := range vs {
+			s, ok := byDir[dir]
+			if !ok {
+				r.skip(Problem{Reason: "skill variant without SKILL.md", Path: v.File, Err: ErrVariantWithoutSkill})
+				dropped++
+				continue
+			}
+			if skills[s.QualifiedName()] != s {
+				continue
+			}
+			if v.Name != s.Name {
+				err := fmt.Errorf("%w: %q in %s, want %q", ErrVariantNameMismatch, v.Name, v.File, s.Name)
+				r.skip(Problem{Reason: "skill variant", Skill: s.QualifiedName(), Path: v.File, Err: err})
+				dropped++
+				continue
+			}
+			if v.Extends != "" || len(v.SectionModes) > 0 {
+				err := fmt.Errorf("%w: %s", ErrVariantInheritance, v.File)
+				r.skip(Problem{Reason: "skill variant", Skill: s.QualifiedName(), Path: v.File, Err: err})
+				dropped++
+				continue
+			}
+			if s.Variants == nil {
+				s.Variants = make(map[string]*skill.Skill)
+			}
+			if existing, ok := s.Variants[v.Locale]; ok {
+				r.logger.Warn("duplicate skill variant",
+					"name", s.Name,
+					"locale", v.Locale,
+					"path", v.File,
+					"existing", existing.File,
+				)
+				continue
+			}
+			s.Variants[v.Locale] = v
+		}
+	}
+	return dropped
+}
+
+// resolveVariants resolves the includes of each of s's variants and, when s
+// extends a parent, merges the variant onto the parent's best matching
+// variant. The base skill s must already be resolved. Variants that fail are
+// removed from s and recorded in rv.variantErrs.
+func (rv *resolver) resolveVariants(s *skill.Skill) {
+	for locale, v := range s.Variants {
+		if err := rv.resolveVariant(s, v); err != nil {
+			rv.variantErrs[v.File] = err
+			delete(s.Variants, locale)
+		}
+	}
+}
+
+// resolveVariant does the work of resolveVariants for a single variant.
+func (rv *resolver) resolveVariant(s, v *skill.Skill) error {
+	text, files, _, err := rv.expand(v, v.Source, v.File, []string{v.File}, 0)
+	if err != nil {
+		return err
+	}
+	if s.Extends != "" {
+		key, _ := rv.lookup(s, s.Extends)
+		parent := rv.skills[key].Localize(v.Locale)
+		text, err = mergeInstructions(parent.Instructions, text, s.SectionModes)
+		if err != nil {
+			return fmt.Errorf("extend %q: %w", s.Extends, err)
+		}
+		files = append(files, parent.File)
+		files = append(files, parent.Includes...)
+	}
+	v.Instructions = strings.TrimSpace(text)
+	v.Includes = compactPaths(files)
+	v.Lineage = s.Lineage
+	return nil
+}
@@ -0,0 +1,90 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVariantLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		ok     bool
+	}{
+		{"SKILL.ja.md", "ja", true},
+		{"SKILL.pt-BR.md", "pt-br", true},
+		{"SKILL.zh_Hant.md", "zh-hant", true},
+		{"SKILL.md", "", false},
+		{"SKILL..md", "", false},
+		{"SKILL.j.md", "", false},
+		{"README.ja.md", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, ok := variantLocale(tt.name)
+			if locale != tt.locale || ok != tt.ok {
+				t.Errorf("variantLocale(%q) = %q, %v, want %q, %v", tt.name, locale, ok, tt.locale, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRegistryVariants(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "greet", "SKILL.md"), "---\nname: greet\ndescription: Greet the user\n---\n\nSay hello.\n")
+	writeFile(t, filepath.Join(tmpDir, "greet", "SKILL.ja.md"), "---\nname: greet\ndescription: ユーザーに挨拶する\n---\n\nこんにちはと言う。\n\n<!-- include: ja/extra.md -->\n")
+	writeFile(t, filepath.Join(tmpDir, "greet", "ja", "extra.md"), "丁寧に。\n")
+	writeFile(t, filepath.Join(tmpDir, "greet", "SKILL.de.md"), "---\nname: gruss\ndescription: Begrüßen\n---\n\nHallo sagen.\n")
+	writeFile(t, filepath.Join(tmpDir, "orphan", "SKILL.fr.md"), "---\nname: orphan\ndescription: Orphelin\n---\n\nBonjour.\n")
+	writeFile(t, filepath.Join(tmpDir, "greet", "SKILL.fr.md"), "---\nname: greet\nextends: other\n---\n\nDire bonjour.\n")
+	writeFile(t, filepath.Join(tmpDir, "greet", "SKILL.es.md"), "---\nname: greet\ndescription: Saludar\nsections:\n  Notes: append\n---\n\nDecir hola.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if reg.Count() != 1 {
+		t.Fatalf("Count() = %d, want 1", reg.Count())
+	}
+	if got := reg.Status().Errors; got != 4 {
+		t.Errorf("Status().Errors = %d, want 4 for the mismatched, orphaned and inheriting variants", got)
+	}
+	var inheriting int
+	for _, p := range reg.Problems() {
+		if errors.Is(p.Err, ErrVariantInheritance) {
+			inheriting++
+		}
+	}
+	if inheriting != 2 {
+		t.Errorf("got %d ErrVariantInheritance problems, want 2 for the fr and es variants", inheriting)
+	}
+
+	greet := reg.Get("greet")
+	if greet == nil {
+		t.Fatal("Get(greet) returned nil")
+	}
+	if len(greet.Variants) != 1 {
+		t.Fatalf("Variants = %v, want only ja", greet.Locales())
+	}
+
+	ja := greet.Localize("ja-JP")
+	if ja.Locale != "ja" {
+		t.Fatalf("Localize(ja-JP).Locale = %q, want ja", ja.Locale)
+	}
+	if ja.Instructions != "こんにちはと言う。\n\n丁寧に。" {
+		t.Errorf("ja Instructions = %q", ja.Instructions)
+	}
+	if ja.Tokens == 0 {
+		t.Error("ja Tokens should be estimated")
+	}
+	if greet.Localize("de") != greet {
+		t.Error("Localize(de) should fall back to the default skill")
+	}
+}
@@ -0,0 +1,69 @@
+package registry
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// NamespaceFilter selects skills by namespace. A pattern matches a
+// namespace equal to it or nested below it, so "backend" matches
+// "backend" and "backend.api".
+type NamespaceFilter struct {
+	// Include, if not empty, limits skills to those in a matching namespace.
+	Include []string
+
+	// Exclude removes skills in a matching namespace, after Include.
+	Exclude []string
+}
+
+// Match reports whether the filter admits skills in namespace.
+func (f NamespaceFilter) Match(namespace string) bool {
+	if len(f.Include) > 0 && !matchNamespace(f.Include, namespace) {
+		return false
+	}
+	return !matchNamespace(f.Exclude, namespace)
+}
+
+// Empty reports whether the filter admits every namespace.
+func (f NamespaceFilter) Empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// matchNamespace reports whether namespace equals or is nested below any
+// of patterns.
+func matchNamespace(patterns []string, namespace string) bool {
+	for _, p := range patterns {
+		p = strings.Trim(p, ".")
+		if namespace == p || strings.HasPrefix(namespace, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceFor returns the namespace of the skill at dir, a skill directory
+// or single-file skill: the path of dir's parent relative to root, with
+// path separators replaced by dots.
+func namespaceFor(root, dir string) string {
+	rel, err := filepath.Rel(root, filepath.Dir(dir))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", ".")
+}
+
+// lookup returns the registry key of the skill a reference from s names,
+// preferring a skill in s's own namespace over one with that qualified name.
+func (rv *resolver) lookup(from *skill.Skill, name string) (string, bool) {
+	if from.Namespace != "" {
+		if key := from.Namespace + "." + name; rv.skills[key] != nil {
+			return key, true
+		}
+	}
+	if rv.skills[name] != nil {
+		return name, true
+	}
+	return "", false
+}
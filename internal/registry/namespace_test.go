@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNamespaceFilterMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    NamespaceFilter
+		namespace string
+		want      bool
+	}{
+		{name: "empty filter", namespace: "backend", want: true},
+		{name: "include exact", filter: NamespaceFilter{Include: []string{"backend"}}, namespace: "backend", want: true},
+		{name: "include nested", filter: NamespaceFilter{Include: []string{"backend"}}, namespace: "backend.api", want: true},
+		{name: "include prefix is not a parent", filter: NamespaceFilter{Include: []string{"back"}}, namespace: "backend", want: false},
+		{name: "include excludes root", filter: NamespaceFilter{Include: []string{"backend"}}, namespace: "", want: false},
+		{name: "exclude", filter: NamespaceFilter{Exclude: []string{"backend.legacy"}}, namespace: "backend.legacy.v1", want: false},
+		{name: "exclude within include", filter: NamespaceFilter{Include: []string{"backend"}, Exclude: []string{"backend.legacy"}}, namespace: "backend.api", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.namespace); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceFor(t *testing.T) {
+	root := filepath.Join("srv", "skills")
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{filepath.Join(root, "testing"), ""},
+		{filepath.Join(root, "backend", "testing"), "backend"},
+		{filepath.Join(root, "backend", "api", "testing"), "backend.api"},
+		{root, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := namespaceFor(root, tt.dir); got != tt.want {
+				t.Errorf("namespaceFor(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryNamespaces(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "review", "SKILL.md"), "---\nname: review\ndescription: Review code\n---\n\n## Style\n\nBe consistent.\n")
+	writeFile(t, filepath.Join(tmpDir, "backend", "review", "SKILL.md"), "---\nname: review\ndescription: Review backend code\n---\n\n## Style\n\nUse gofmt.\n")
+	writeFile(t, filepath.Join(tmpDir, "backend", "testing", "SKILL.md"), "---\nname: testing\nextends: review\n---\n\n## Tests\n\nRun go test.\n")
+	writeFile(t, filepath.Join(tmpDir, "frontend", "testing", "SKILL.md"), "---\nname: testing\ndescription: Test frontend code\n---\n\n<!-- include: skill:review -->\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	t.Run("disabled", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger)
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		if reg.Get("testing") == nil || reg.Get("backend.testing") != nil {
+			t.Error("without namespaces, skills should register under their plain names")
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger, WithNamespaces(true))
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+
+		var names, tools []string
+		for _, s := range reg.List() {
+			names = append(names, s.QualifiedName())
+			tools = append(tools, s.Tool)
+		}
+		if got := strings.Join(names, ","); got != "review,backend.review,backend.testing,frontend.testing" {
+			t.Errorf("List() = %s", got)
+		}
+		if got := strings.Join(tools, ","); got != "review,backend__review,backend__testing,frontend__testing" {
+			t.Errorf("tool names = %s", got)
+		}
+
+		backend := reg.Get("backend.testing")
+		if !strings.Contains(backend.Instructions, "Use gofmt.") {
+			t.Errorf("backend.testing should extend backend.review:\n%s", backend.Instructions)
+		}
+		if backend.Description != "Review backend code" {
+			t.Errorf("backend.testing Description = %q", backend.Description)
+		}
+		frontend := reg.Get("frontend.testing")
+		if !strings.Contains(frontend.Instructions, "Be consistent.") {
+			t.Errorf("frontend.testing should include the root review:\n%s", frontend.Instructions)
+		}
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		reg := NewRegistry(tmpDir, logger,
+			WithNamespaces(true),
+			WithNamespaceFilter(NamespaceFilter{Include: []string{"backend"}}),
+		)
+		if err := reg.Scan(); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+
+		var names []string
+		for _, s := range reg.List() {
+			names = append(names, s.QualifiedName())
+		}
+		if got := strings.Join(names, ","); got != "backend.review,backend.testing" {
+			t.Errorf("List() = %s, want backend.review,backend.testing", got)
+		}
+	})
+}
@@ -3,8 +3,12 @@ package registry
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -17,15 +21,17 @@ var (
 	ErrNoFrontmatter = errors.New("no YAML frontmatter found")
 	// ErrMissingName is returned when a skill's frontmatter lacks the required name field.
 	ErrMissingName = errors.New("skill name is required")
-	// ErrMissingDesc is returned when a skill's frontmatter lacks the required description field.
+	// ErrMissingDesc is returned when a skill's frontmatter lacks the required
+	// description field and does not extend another skill.
 	ErrMissingDesc = errors.New("skill description is required")
 	// ErrFileTooLarge is returned when a SKILL.md file exceeds MaxSkillFileSize.
 	ErrFileTooLarge = errors.New("skill file exceeds maximum size")
 )
 
-// MaxSkillFileSize is the maximum allowed size for a SKILL.md file (64KB).
-// This limit ensures skills remain token-efficient for LLM context windows.
-const MaxSkillFileSize = 64 << 10
+// MaxSkillFileSize is the maximum allowed size for a SKILL.md file (1MB).
+// It only guards against reading unreasonably large files; how much of a
+// model's context a skill may use is governed by the registry's TokenBudget.
+const MaxSkillFileSize = 1 << 20
 
 // ParseSkillMD parses a SKILL.md file and returns a Skill.
 // The file must contain YAML frontmatter between --- markers.
@@ -39,14 +45,33 @@ func ParseSkillMD(path string) (*skill.Skill, error) {
 		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrFileTooLarge, info.Size(), MaxSkillFileSize)
 	}
 
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("open skill file: %w", err)
+		return nil, fmt.Errorf("read skill file: %w", err)
 	}
-	defer f.Close()
+	return ParseSkillMDBytes(data)
+}
 
+// ParseSkillMDBytes parses the content of a SKILL.md file, as ParseSkillMD
+// does for a file on disk. The skill's Hash and Size describe data.
+func ParseSkillMDBytes(data []byte) (*skill.Skill, error) {
+	if len(data) > MaxSkillFileSize {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrFileTooLarge, len(data), MaxSkillFileSize)
+	}
+	s, err := parseSkill(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	s.Hash = hex.EncodeToString(sum[:])
+	s.Size = int64(len(data))
+	return s, nil
+}
+
+// parseSkill parses SKILL.md content read from r.
+func parseSkill(r io.Reader) (*skill.Skill, error) {
 	var (
-		scanner       = bufio.NewScanner(f)
+		scanner       = bufio.NewScanner(r)
 		inFrontmatter bool
 		frontmatter   strings.Builder
 		content       strings.Builder
@@ -96,9 +121,14 @@ func ParseSkillMD(path string) (*skill.Skill, error) {
 	if s.Name == "" {
 		return nil, ErrMissingName
 	}
-	if s.Description == "" {
+	if s.Description == "" && s.Extends == "" {
 		return nil, ErrMissingDesc
 	}
+	for heading, mode := range s.SectionModes {
+		if !validSectionMode(mode) {
+			return nil, fmt.Errorf("%w: %q for section %q", ErrInvalidSectionMode, mode, heading)
+		}
+	}
 
 	s.Instructions = strings.TrimSpace(content.String())
 
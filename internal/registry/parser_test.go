@@ -1,9 +1,12 @@
 package registry
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -69,6 +72,31 @@ Content.
 name: no-desc
 ---
 
+Content.
+`,
+			wantErr: true,
+		},
+		{
+			name: "extends without description",
+			content: `---
+name: child
+extends: parent
+---
+
+Content.
+`,
+			wantName:  "child",
+			wantInstr: "Content.",
+		},
+		{
+			name: "invalid section mode",
+			content: `---
+name: child
+extends: parent
+sections:
+  Setup: delete
+---
+
 Content.
 `,
 			wantErr: true,
@@ -135,3 +163,70 @@ description: A very large skill
 		t.Errorf("expected ErrFileTooLarge, got %v", err)
 	}
 }
+
+func TestParseSkillMDBytes(t *testing.T) {
+	skill, err := ParseSkillMDBytes([]byte("---\nname: review\ndescription: Review code\n---\n\nCheck tests.\n"))
+	if err != nil {
+		t.Fatalf("ParseSkillMDBytes() error: %v", err)
+	}
+	if skill.Name != "review" || skill.Description != "Review code" || skill.Instructions != "Check tests." {
+		t.Errorf("ParseSkillMDBytes() = %+v", skill)
+	}
+	if skill.Path != "" || skill.File != "" {
+		t.Errorf("ParseSkillMDBytes() set a path: %q, %q", skill.Path, skill.File)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"no frontmatter", "# Review\n", ErrNoFrontmatter},
+		{"too large", "---\nname: large\n---\n\n" + strings.Repeat("x", MaxSkillFileSize+1), ErrFileTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSkillMDBytes([]byte(tt.content)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSkillMDBytes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSkillMDTagsHashAndSize(t *testing.T) {
+	content := `---
+name: tagged
+description: A tagged skill
+tags: [go, testing]
+---
+
+Instructions.
+`
+	skillPath := filepath.Join(t.TempDir(), "SKILL.md")
+	if err := os.WriteFile(skillPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	s, err := ParseSkillMD(skillPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(s.Tags, []string{"go", "testing"}) {
+		t.Errorf("tags = %q, want [go testing]", s.Tags)
+	}
+	sum := sha256.Sum256([]byte(content))
+	if want := hex.EncodeToString(sum[:]); s.Hash != want {
+		t.Errorf("hash = %q, want %q", s.Hash, want)
+	}
+	if s.Size != int64(len(content)) {
+		t.Errorf("size = %d, want %d", s.Size, len(content))
+	}
+
+	other, err := ParseSkillMDBytes([]byte(strings.Replace(content, "Instructions.", "Changed.", 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.Hash == s.Hash {
+		t.Error("different content has the same hash")
+	}
+}
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"sort"
+)
+
+// Problem is a skill, variant or path the last scan skipped.
+type Problem struct {
+	// Reason says what the scan was doing, e.g. "parse skill".
+	Reason string
+
+	// Path is the file or directory that was skipped.
+	Path string
+
+	// Skill is the qualified name of the skill concerned, if known.
+	Skill string
+
+	// Err explains why it was skipped.
+	Err error
+}
+
+// skip logs a problem found during a scan and records it for Problems.
+// It is safe to call from concurrent parsers.
+func (r *Registry) skip(p Problem) {
+	r.problemMu.Lock()
+	r.scanProblems = append(r.scanProblems, p)
+	r.problemMu.Unlock()
+
+	var args []any
+	if p.Skill != "" {
+		args = append(args, "name", p.Skill)
+	}
+	args = append(args, "path", p.Path, "error", p.Err)
+	r.logger.Warn(p.Reason, args...)
+}
+
+// takeProblems returns the problems recorded during the current scan,
+// sorted by path, and resets them for the next.
+func (r *Registry) takeProblems() []Problem {
+	r.problemMu.Lock()
+	defer r.problemMu.Unlock()
+	problems := r.scanProblems
+	r.scanProblems = nil
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems
+}
+
+// Problems returns the skills, variants and paths the last successful scan
+// skipped and why, sorted by path. Duplicates are reported by Conflicts.
+func (r *Registry) Problems() []Problem {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.problems
+}
@@ -1,108 +1,415 @@
 package registry
 
 import (
+	"context"
 	"fmt"
-	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/portertech/skills-mcp-server/internal/telemetry"
+	"github.com/portertech/skills-mcp-server/internal/tokens"
 	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 const skillFileName = "SKILL.md"
 
+// DefaultSkillFilePattern matches the names of single-file skills, which
+// may appear anywhere under the root alongside skill directories.
+const DefaultSkillFilePattern = "*.skill.md"
+
 // Registry manages skill discovery and retrieval.
 type Registry struct {
 	root     string
 	skills   map[string]*skill.Skill
 	toolName map[string]string // maps tool name -> skill name for collision detection
+	status   ScanStatus
 	mu       sync.RWMutex
+	scanMu   sync.Mutex // serializes scans
 	logger   *slog.Logger
+
+	estimator tokens.Estimator
+	budget    TokenBudget
+	naming    ToolNaming
+
+	namespaces bool
+	nsFilter   NamespaceFilter
+	duplicates string
+	conflicts  []Conflict
+
+	problems     []Problem
+	scanProblems []Problem  // recorded by the scan in progress
+	problemMu    sync.Mutex // guards scanProblems during concurrent parsing
+
+	scanWorkers      int
+	scanTimeout      time.Duration
+	discovery        Discovery
+	skillFilePattern string
+}
+
+// Option configures optional Registry behavior.
+type Option func(*Registry)
+
+// WithTokenEstimator sets the estimator used to count skill tokens.
+// The default is tokens.Heuristic.
+func WithTokenEstimator(e tokens.Estimator) Option {
+	return func(r *Registry) {
+		r.estimator = e
+	}
+}
+
+// WithTokenBudget sets per-skill and total-catalog token limits.
+func WithTokenBudget(b TokenBudget) Option {
+	return func(r *Registry) {
+		r.budget = b
+	}
+}
+
+// WithToolNaming sets how skill names are converted to MCP tool names.
+// The default is snake_case with no prefix.
+func WithToolNaming(n ToolNaming) Option {
+	return func(r *Registry) {
+		r.naming = n
+	}
+}
+
+// WithNamespaces namespaces skills by the directory containing their skill
+// directory, relative to the root, so backend/testing/SKILL.md and
+// frontend/testing/SKILL.md register as "backend.testing" and
+// "frontend.testing" instead of colliding.
+func WithNamespaces(enabled bool) Option {
+	return func(r *Registry) {
+		r.namespaces = enabled
+	}
+}
+
+// WithNamespaceFilter limits the registered skills to those whose namespace
+// the filter matches. Skills filtered out can still be extended or included
+// by registered skills.
+func WithNamespaceFilter(f NamespaceFilter) Option {
+	return func(r *Registry) {
+		r.nsFilter = f
+	}
+}
+
+// WithDuplicatePolicy sets how skills sharing a name or tool name are
+// settled: DuplicateFirst (the default), DuplicateLast, DuplicateError,
+// DuplicateHighestVersion or DuplicatePriority.
+func WithDuplicatePolicy(policy string) Option {
+	return func(r *Registry) {
+		r.duplicates = policy
+	}
+}
+
+// WithScanWorkers sets how many skill files are parsed concurrently.
+// The default is runtime.GOMAXPROCS(0).
+func WithScanWorkers(n int) Option {
+	return func(r *Registry) {
+		r.scanWorkers = n
+	}
+}
+
+// WithScanTimeout bounds how long a scan may take. A scan that exceeds it
+// is aborted and the previous skills are kept. Zero means no limit.
+func WithScanTimeout(d time.Duration) Option {
+	return func(r *Registry) {
+		r.scanTimeout = d
+	}
+}
+
+// WithDiscovery sets the depth limit, hidden directory handling and symlink
+// policy used when searching the root for skills.
+func WithDiscovery(d Discovery) Option {
+	return func(r *Registry) {
+		r.discovery = d
+	}
+}
+
+// WithSkillFilePattern sets the glob, matched against file names, that
+// identifies single-file skills. The default is DefaultSkillFilePattern;
+// an empty pattern disables single-file skills.
+func WithSkillFilePattern(pattern string) Option {
+	return func(r *Registry) {
+		r.skillFilePattern = pattern
+	}
+}
+
+// ValidateSkillFilePattern reports whether pattern is a valid glob.
+func ValidateSkillFilePattern(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("skill file pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// ScanStatus describes the outcome of the most recent scan.
+type ScanStatus struct {
+	// Scanned is true once at least one scan has completed, successfully or not.
+	Scanned bool
+
+	// LastScan is when the most recent scan completed.
+	LastScan time.Time
+
+	// LastError is the error returned by the most recent scan, if any.
+	LastError error
+
+	// Errors is the cumulative number of scan failures and unparseable
+	// SKILL.md files since the registry was created.
+	Errors int
+}
+
+// Ready reports whether the registry has completed a scan and the most
+// recent scan succeeded.
+func (s ScanStatus) Ready() bool {
+	return s.Scanned && s.LastError == nil
 }
 
 // NewRegistry creates a new skill registry rooted at the given directory.
-func NewRegistry(root string, logger *slog.Logger) *Registry {
+func NewRegistry(root string, logger *slog.Logger, opts ...Option) *Registry {
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	}
-	return &Registry{
-		root:     root,
-		skills:   make(map[string]*skill.Skill),
-		toolName: make(map[string]string),
-		logger:   logger,
+	r := &Registry{
+		root:      root,
+		skills:    make(map[string]*skill.Skill),
+		toolName:  make(map[string]string),
+		logger:    logger,
+		estimator: tokens.Heuristic{},
+
+		skillFilePattern: DefaultSkillFilePattern,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 // Scan discovers all skills in the registry root directory.
+// It is ScanContext with a background context.
 func (r *Registry) Scan() error {
+	return r.ScanContext(context.Background())
+}
+
+// ScanContext discovers all skills in the registry root directory, parsing
+// them concurrently with a bounded pool of workers. The new index is built
+// without holding the registry lock and swapped in when complete, so
+// readers see either the previous skills or the new ones.
+//
+// If the root cannot be walked, ctx is cancelled, or the scan timeout
+// passes, the previously discovered skills are kept and the error is
+// recorded in the registry's ScanStatus. Concurrent calls are serialized.
+func (r *Registry) ScanContext(ctx context.Context) error {
+	r.scanMu.Lock()
+	defer r.scanMu.Unlock()
+
+	if r.scanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.scanTimeout)
+		defer cancel()
+	}
+
+	ctx, span := telemetry.Tracer().Start(ctx, "registry.Scan")
+	defer span.End()
+	span.SetAttributes(telemetry.AttrRoot.String(r.root))
+
+	var (
+		start     = time.Now()
+		parsed    []*skill.Skill
+		errCount  int
+		skills    map[string]*skill.Skill
+		toolNames map[string]string
+		conflicts []Conflict
+	)
+
+	r.takeProblems()
+	files, err := r.discover(ctx)
+	if err == nil {
+		parsed, errCount, err = r.parseAll(ctx, files)
+	}
+	candidates, variants, invalid := r.collect(parsed)
+	errCount += invalid
+
+	if err == nil {
+		skills, toolNames, conflicts, err = r.dedupe(candidates)
+	}
+	if err == nil {
+		errCount += r.attachVariants(skills, variants, candidates)
+		errCount += r.finalize(skills, toolNames)
+		err = ctx.Err()
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.skills = make(map[string]*skill.Skill)
-	r.toolName = make(map[string]string)
+	r.status.Scanned = true
+	r.status.LastScan = time.Now()
+	r.status.LastError = err
+	r.status.Errors += errCount
+	if err != nil {
+		r.status.Errors++
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
 
-	return filepath.WalkDir(r.root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			r.logger.Warn("walk error", "path", path, "error", err)
-			return nil
-		}
+	r.skills = skills
+	r.toolName = toolNames
+	r.conflicts = conflicts
+	r.problems = r.takeProblems()
 
-		if d.IsDir() {
-			return nil
-		}
+	telemetry.RecordScan(ctx, r.root, time.Since(start), len(r.skills))
+	span.SetAttributes(attribute.Int("skills.count", len(r.skills)))
+	return nil
+}
 
-		if d.Name() != skillFileName {
-			return nil
-		}
+// finalize resolves includes and extends chains in freshly discovered skills
+// and their localized variants, derives their token counts and sections, and
+// applies token budgets. Skills and variants that cannot be resolved or are
+// rejected are removed. It returns the number that failed to resolve.
+func (r *Registry) finalize(skills map[string]*skill.Skill, toolNames map[string]string) int {
+	remove := func(name string) {
+		delete(toolNames, skills[name].Tool)
+		delete(skills, name)
+	}
+
+	rv := newResolver(skills)
+	var failed int
+	for name, err := range rv.resolveAll() {
+		r.skip(Problem{Reason: "resolve skill", Skill: name, Path: skills[name].File, Err: err})
+		remove(name)
+		failed++
+	}
+	for file, err := range rv.variantErrs {
+		r.skip(Problem{Reason: "resolve skill variant", Path: file, Err: err})
+		failed++
+	}
 
-		s, err := ParseSkillMD(path)
-		if err != nil {
-			r.logger.Warn("parse skill", "path", path, "error", err)
-			return nil
+	for name, s := range skills {
+		if !r.nsFilter.Match(s.Namespace) {
+			r.logger.Debug("filtered skill", "name", name, "namespace", s.Namespace)
+			remove(name)
 		}
+	}
 
-		s.Path = filepath.Dir(path)
+	for name, s := range skills {
+		s.Tokens = r.estimator.Count(s.Instructions)
+		s.Sections = ParseSections(s.Instructions)
 
-		if existing, ok := r.skills[s.Name]; ok {
-			r.logger.Warn("duplicate skill name",
-				"name", s.Name,
-				"path", path,
-				"existing", existing.Path,
-			)
-			return nil
+		for locale, v := range s.Variants {
+			v.Tokens = r.estimator.Count(v.Instructions)
+			v.Sections = ParseSections(v.Instructions)
+			if err := r.budget.checkSkill(v); err != nil {
+				if r.budget.Reject {
+					r.skip(Problem{Reason: "rejected skill variant", Skill: name, Path: v.File, Err: err})
+					delete(s.Variants, locale)
+					continue
+				}
+				r.logger.Warn("skill variant over token budget", "name", name, "path", v.File, "error", err)
+			}
 		}
 
-		// Check for tool name collision after normalization
-		toolName := ToolNameForSkill(s.Name)
-		if existingName, ok := r.toolName[toolName]; ok {
-			r.logger.Warn("tool name collision",
-				"tool_name", toolName,
-				"skill", s.Name,
-				"existing_skill", existingName,
-			)
-			return nil
+		if err := r.budget.checkSkill(s); err != nil {
+			if r.budget.Reject {
+				r.skip(Problem{Reason: "rejected skill", Skill: name, Path: s.File, Err: err})
+				remove(name)
+				continue
+			}
+			r.logger.Warn("skill over token budget", "name", name, "path", s.File, "error", err)
 		}
-		r.toolName[toolName] = s.Name
+	}
 
-		r.skills[s.Name] = s
-		r.logger.Debug("discovered skill", "name", s.Name, "path", s.Path)
+	r.applyTotalBudget(skills, toolNames)
+	return failed
+}
 
-		return nil
-	})
+// applyTotalBudget enforces the total-catalog token budget on a freshly
+// scanned index, warning about or removing the skills that overflow it.
+func (r *Registry) applyTotalBudget(skills map[string]*skill.Skill, toolNames map[string]string) {
+	total, over := r.budget.checkTotal(skills)
+	if len(over) == 0 {
+		return
+	}
+	if !r.budget.Reject {
+		r.logger.Warn("skill catalog over token budget",
+			"error", ErrCatalogOverBudget,
+			"tokens", total,
+			"max", r.budget.Total,
+		)
+		return
+	}
+	for _, name := range over {
+		r.skip(Problem{
+			Reason: "rejected skill",
+			Skill:  name,
+			Path:   skills[name].File,
+			Err:    fmt.Errorf("%w: %d tokens would exceed %d", ErrCatalogOverBudget, skills[name].Tokens, r.budget.Total),
+		})
+		delete(toolNames, skills[name].Tool)
+		delete(skills, name)
+	}
+}
+
+// TotalTokens returns the estimated token count of all registered skills.
+func (r *Registry) TotalTokens() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var total int
+	for _, s := range r.skills {
+		total += s.Tokens
+	}
+	return total
+}
+
+// Estimator returns the estimator used to count skill tokens.
+func (r *Registry) Estimator() tokens.Estimator {
+	return r.estimator
+}
+
+// Conflicts returns the name and tool name conflicts settled by the most
+// recent successful scan, including every shadowed candidate.
+func (r *Registry) Conflicts() []Conflict {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.conflicts
 }
 
-// Get retrieves a skill by name.
+// Status returns the outcome of the most recent scan.
+func (r *Registry) Status() ScanStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+// parse parses a single SKILL.md file inside its own span.
+func (r *Registry) parse(ctx context.Context, path string) (*skill.Skill, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "registry.ParseSkillMD")
+	defer span.End()
+	span.SetAttributes(telemetry.AttrSkillPath.String(path))
+
+	s, err := ParseSkillMD(path)
+	if err != nil {
+		telemetry.RecordParseFailure(ctx)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrSkillName.String(s.Name))
+	return s, nil
+}
+
+// Get retrieves a skill by its qualified name.
 func (r *Registry) Get(name string) *skill.Skill {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	return r.skills[name]
 }
 
-// List returns all discovered skills sorted by name.
+// List returns all discovered skills sorted by namespace, then name.
 func (r *Registry) List() []*skill.Skill {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -112,6 +419,9 @@ func (r *Registry) List() []*skill.Skill {
 		skills = append(skills, s)
 	}
 	sort.Slice(skills, func(i, j int) bool {
+		if skills[i].Namespace != skills[j].Namespace {
+			return skills[i].Namespace < skills[j].Namespace
+		}
 		return skills[i].Name < skills[j].Name
 	})
 	return skills
@@ -122,6 +432,18 @@ func (r *Registry) Root() string {
 	return r.root
 }
 
+// RelPath returns path relative to root with forward slashes, as used to
+// show skill files in listings and reports. A path outside root starts with
+// ../ elements; path is returned unchanged only if it cannot be made
+// relative to root, e.g. when one is absolute and the other is not.
+func RelPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
 // Count returns the number of discovered skills.
 func (r *Registry) Count() int {
 	r.mu.RLock()
@@ -134,11 +456,10 @@ func (r *Registry) String() string {
 	return fmt.Sprintf("Registry{root=%s, skills=%d}", r.root, r.Count())
 }
 
-// ToolNameForSkill converts a skill name to a valid MCP tool name.
-// Lowercases the name and replaces spaces and hyphens with underscores.
+// ToolNameForSkill converts a skill name to a tool name with the default
+// snake_case strategy. Lowercases the name and replaces every character
+// other than a-z and 0-9 with an underscore. The result may still be
+// invalid, e.g. empty or too long; see ValidateToolName.
 func ToolNameForSkill(name string) string {
-	name = strings.ToLower(name)
-	name = strings.ReplaceAll(name, " ", "_")
-	name = strings.ReplaceAll(name, "-", "_")
-	return name
+	return normalizeToolName(name, '_')
 }
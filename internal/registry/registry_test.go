@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -198,6 +199,10 @@ func TestToolNameForSkill(t *testing.T) {
 		{"Code Review", "code_review"},
 		{"my_skill", "my_skill"},
 		{"My-Skill-Name", "my_skill_name"},
+		{"api.v2/client", "api_v2_client"},
+		{"café menu", "caf_menu"},
+		{"backend  testing", "backend_testing"},
+		{"--code -- review--", "code_review"},
 	}
 
 	for _, tt := range tests {
@@ -209,3 +214,56 @@ func TestToolNameForSkill(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryTokenBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for name, body := range map[string]string{
+		"small":  "Short.",
+		"medium": strings.Repeat("word ", 40),
+		"large":  strings.Repeat("word ", 400),
+	} {
+		dir := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create %s dir: %v", name, err)
+		}
+		content := "---\nname: " + name + "\ndescription: The " + name + " skill\n---\n\n" + body + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name   string
+		budget TokenBudget
+		want   []string
+	}{
+		{"unlimited", TokenBudget{}, []string{"large", "medium", "small"}},
+		{"per-skill warn", TokenBudget{PerSkill: 100}, []string{"large", "medium", "small"}},
+		{"per-skill reject", TokenBudget{PerSkill: 100, Reject: true}, []string{"medium", "small"}},
+		// "large" is visited first in name order and alone exceeds the total.
+		{"total reject", TokenBudget{Total: 100, Reject: true}, []string{"medium", "small"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := NewRegistry(tmpDir, logger, WithTokenBudget(tt.budget))
+			if err := reg.Scan(); err != nil {
+				t.Fatalf("Scan() error: %v", err)
+			}
+
+			var got []string
+			for _, s := range reg.List() {
+				if s.Tokens <= 0 {
+					t.Errorf("skill %s has Tokens = %d, want > 0", s.Name, s.Tokens)
+				}
+				got = append(got, s.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("skills = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+var (
+	// ErrParentNotFound is returned when a skill extends a skill that does not exist.
+	ErrParentNotFound = errors.New("extended skill not found")
+	// ErrExtendsCycle is returned when extends chains form a cycle.
+	ErrExtendsCycle = errors.New("extends cycle")
+)
+
+// resolver expands include directives and extends chains across a set of
+// skills, memoizing each skill so shared parents and includes are resolved
+// once.
+type resolver struct {
+	skills map[string]*skill.Skill
+	errs   map[string]error
+	depth  map[string]int // deepest include nesting used by a resolved skill
+	done   map[string]bool
+	active map[string]bool
+
+	variantErrs map[string]error // keyed by variant file
+}
+
+// newResolver returns a resolver over skills.
+func newResolver(skills map[string]*skill.Skill) *resolver {
+	return &resolver{
+		skills:      skills,
+		errs:        make(map[string]error),
+		depth:       make(map[string]int),
+		done:        make(map[string]bool),
+		active:      make(map[string]bool),
+		variantErrs: make(map[string]error),
+	}
+}
+
+// resolveSkills resolves the Source of every skill into its final
+// Instructions, Includes, Lineage and inherited Description. It returns the
+// skills that could not be resolved, keyed by name.
+func resolveSkills(skills map[string]*skill.Skill) map[string]error {
+	return newResolver(skills).resolveAll()
+}
+
+// resolveAll resolves every skill and its localized variants. It returns the
+// skills that could not be resolved, keyed by name; variants that could not
+// be resolved are dropped and recorded in rv.variantErrs.
+func (rv *resolver) resolveAll() map[string]error {
+	failed := make(map[string]error)
+	for name := range rv.skills {
+		if err := rv.resolve(name); err != nil {
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// resolve resolves the named skill: its own includes first, then the merge
+// onto its resolved parent. Cycles through includes or extends are detected
+// via the set of skills currently being resolved.
+func (rv *resolver) resolve(name string) error {
+	if rv.done[name] {
+		return rv.errs[name]
+	}
+	if rv.active[name] {
+		return fmt.Errorf("%w: skill %q", ErrIncludeCycle, name)
+	}
+	rv.active[name] = true
+	defer delete(rv.active, name)
+
+	err := rv.resolveSkill(rv.skills[name])
+	rv.done[name] = true
+	rv.errs[name] = err
+	return err
+}
+
+// resolveSkill does the work of resolve for a single skill.
+func (rv *resolver) resolveSkill(s *skill.Skill) error {
+	text, files, depth, err := rv.expand(s, s.Source, s.File, []string{s.File}, 0)
+	if err != nil {
+		return err
+	}
+	lineage := []string{s.QualifiedName()}
+
+	if s.Extends != "" {
+		key, ok := rv.lookup(s, s.Extends)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrParentNotFound, s.Extends)
+		}
+		if rv.active[key] {
+			return fmt.Errorf("%w: %s -> %s", ErrExtendsCycle, s.QualifiedName(), key)
+		}
+		if err := rv.resolve(key); err != nil {
+			return fmt.Errorf("extend %q: %w", s.Extends, err)
+		}
+		parent := rv.skills[key]
+
+		text, err = mergeInstructions(parent.Instructions, text, s.SectionModes)
+		if err != nil {
+			return fmt.Errorf("extend %q: %w", s.Extends, err)
+		}
+		if s.Description == "" {
+			s.Description = parent.Description
+		}
+		files = append(files, parent.File)
+		files = append(files, parent.Includes...)
+		depth = max(depth, rv.depth[key])
+		lineage = append(lineage, parent.Lineage...)
+	}
+
+	rv.depth[s.QualifiedName()] = depth
+	s.Instructions = strings.TrimSpace(text)
+	s.Includes = compactPaths(files)
+	s.Lineage = lineage
+	rv.resolveVariants(s)
+	return nil
+}
@@ -0,0 +1,329 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// skillFile is a SKILL.md, localized SKILL.<lang>.md or single-file skill
+// found by discover.
+type skillFile struct {
+	path   string
+	locale string // empty unless a localized variant
+	single bool   // a single-file skill matching the skill file pattern
+}
+
+// discover walks the root for skill files, returning them in lexical path
+// order. Each directory is searched at most once, however many symlinks
+// lead to it. It honors the registry's Discovery settings and ignore files, and
+// stops with ctx's error if ctx is done.
+func (r *Registry) discover(ctx context.Context) ([]skillFile, error) {
+	realRoot, err := filepath.EvalSymlinks(r.root)
+	if err != nil {
+		return nil, fmt.Errorf("walk skills root: %w", err)
+	}
+	w := &walker{
+		r:        r,
+		ctx:      ctx,
+		realRoot: realRoot,
+		visited:  map[string]bool{realRoot: true},
+	}
+	if err := w.walk(r.root, 0, nil); err != nil {
+		return nil, err
+	}
+	// Symlinked directories are entered after everything else, so a
+	// directory reachable both directly and through a link is found at its
+	// own path.
+	for len(w.links) > 0 {
+		link := w.links[0]
+		w.links = w.links[1:]
+		if w.visited[link.target] {
+			w.r.logger.Debug("skipping symlink to a directory already searched", "path", link.path, "target", link.target)
+			continue
+		}
+		w.visited[link.target] = true
+		if err := w.walk(link.path, link.depth, link.ignores); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(w.files, func(i, j int) bool {
+		return w.files[i].path < w.files[j].path
+	})
+	return w.files, nil
+}
+
+// Files returns the paths of the skill files under the root that a scan
+// would read, whether or not they parse, in lexical order. Like a scan, it
+// honors the registry's Discovery settings, ignore files and skill file
+// pattern.
+func (r *Registry) Files(ctx context.Context) ([]string, error) {
+	files, err := r.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+	return paths, nil
+}
+
+// dirLink is a symlinked directory waiting to be searched.
+type dirLink struct {
+	path    string
+	target  string
+	depth   int
+	ignores []*ignoreFile
+}
+
+// walker carries the state of one discovery walk.
+type walker struct {
+	r        *Registry
+	ctx      context.Context
+	realRoot string
+	visited  map[string]bool // real paths of directories entered
+	links    []dirLink
+	files    []skillFile
+}
+
+// walk visits dir, at the given depth below the root, with the ignore files
+// of its ancestors in effect.
+func (w *walker) walk(dir string, depth int, ignores []*ignoreFile) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if depth == 0 {
+			return fmt.Errorf("walk skills root: %w", err)
+		}
+		w.r.skip(Problem{Reason: "walk error", Path: dir, Err: err})
+		return nil
+	}
+
+	ig, err := loadIgnoreFile(dir)
+	if err != nil {
+		w.r.logger.Warn("ignore file", "path", filepath.Join(dir, ignoreFileName), "error", err)
+	}
+	if ig != nil {
+		ignores = append(slices.Clip(ignores), ig)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		isDir := entry.IsDir()
+		realPath := ""
+		if entry.Type()&fs.ModeSymlink != 0 {
+			var ok bool
+			realPath, isDir, ok = w.followLink(path)
+			if !ok {
+				continue
+			}
+		}
+
+		if ignored(ignores, path, isDir) {
+			w.r.logger.Debug("ignored path", "path", path)
+			continue
+		}
+
+		if !isDir {
+			if f, ok := w.r.classify(path); ok {
+				w.files = append(w.files, f)
+			}
+			continue
+		}
+
+		if w.r.discovery.excludesDir(entry.Name(), depth) {
+			continue
+		}
+		if limit := w.r.discovery.MaxDepth; limit > 0 && depth+1 > limit {
+			continue
+		}
+		if realPath != "" {
+			w.links = append(w.links, dirLink{path: path, target: realPath, depth: depth + 1, ignores: ignores})
+			continue
+		}
+		if realPath, err = filepath.EvalSymlinks(path); err != nil {
+			w.r.skip(Problem{Reason: "walk error", Path: path, Err: err})
+			continue
+		}
+		if w.visited[realPath] {
+			continue
+		}
+		w.visited[realPath] = true
+
+		if err := w.walk(path, depth+1, ignores); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classify reports whether the file at path is a skill file, and of which kind.
+func (r *Registry) classify(path string) (skillFile, bool) {
+	name := filepath.Base(path)
+	if name == skillFileName {
+		return skillFile{path: path}, true
+	}
+	if locale, ok := variantLocale(name); ok {
+		return skillFile{path: path, locale: locale}, true
+	}
+	if r.skillFilePattern != "" {
+		if ok, _ := filepath.Match(r.skillFilePattern, name); ok {
+			return skillFile{path: path, single: true}, true
+		}
+	}
+	return skillFile{}, false
+}
+
+// followLink applies the symlink policy to the link at path. It returns the
+// link's resolved target and whether it is a directory, or false if the
+// link should be skipped.
+func (w *walker) followLink(path string) (string, bool, bool) {
+	policy := w.r.discovery.Symlinks
+	if policy == SymlinksIgnore {
+		w.r.logger.Debug("ignored symlink", "path", path)
+		return "", false, false
+	}
+
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		w.r.skip(Problem{Reason: "broken symlink", Path: path, Err: err})
+		return "", false, false
+	}
+	if policy != SymlinksFollow && !within(w.realRoot, target) {
+		w.r.skip(Problem{Reason: "skipping symlink outside the skills root", Path: path, Err: fmt.Errorf("%w: %s", ErrSymlinkOutsideRoot, target)})
+		return "", false, false
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		w.r.skip(Problem{Reason: "walk error", Path: path, Err: err})
+		return "", false, false
+	}
+	return target, info.IsDir(), true
+}
+
+// within reports whether path is dir or lies below it.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// parseAll parses files concurrently with the registry's worker count. It
+// returns the parsed skills in the order of files, skipping those that
+// failed, and the number that failed. It stops with ctx's error if ctx is
+// done.
+func (r *Registry) parseAll(ctx context.Context, files []skillFile) ([]*skill.Skill, int, error) {
+	workers := r.scanWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(files))
+
+	results := make([]*skill.Skill, len(files))
+	failed := make([]bool, len(files))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range jobs {
+				s, err := r.parse(ctx, files[i].path)
+				if err != nil {
+					r.skip(Problem{Reason: "parse skill", Path: files[i].path, Err: err})
+					failed[i] = true
+					continue
+				}
+				results[i] = r.prepare(s, files[i])
+			}
+		})
+	}
+
+	var err error
+send:
+	for i := range files {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		parsed   = make([]*skill.Skill, 0, len(files))
+		errCount int
+	)
+	for i, s := range results {
+		if failed[i] {
+			errCount++
+		} else {
+			parsed = append(parsed, s)
+		}
+	}
+	return parsed, errCount, nil
+}
+
+// prepare fills in the fields of a freshly parsed skill that come from
+// where it was found.
+func (r *Registry) prepare(s *skill.Skill, f skillFile) *skill.Skill {
+	s.Path = filepath.Dir(f.path)
+	s.File = f.path
+	s.Source = s.Instructions
+	s.Locale = f.locale
+	if r.namespaces {
+		if f.single {
+			s.Namespace = namespaceFor(r.root, s.File)
+		} else {
+			s.Namespace = namespaceFor(r.root, s.Path)
+		}
+	}
+	return s
+}
+
+// collect splits parsed skills into duplicate candidates, with their tool
+// names assigned, and localized variants keyed by directory. Skills without
+// a valid tool name are dropped; it returns how many.
+func (r *Registry) collect(parsed []*skill.Skill) ([]*skill.Skill, map[string][]*skill.Skill, int) {
+	var (
+		candidates []*skill.Skill
+		variants   = make(map[string][]*skill.Skill)
+		invalid    int
+	)
+	for _, s := range parsed {
+		if s.Locale != "" {
+			variants[s.Path] = append(variants[s.Path], s)
+			continue
+		}
+
+		toolName, err := r.naming.ToolName(s)
+		if err != nil {
+			r.skip(Problem{Reason: "invalid tool name", Skill: s.QualifiedName(), Path: s.File, Err: err})
+			invalid++
+			continue
+		}
+		s.Tool = toolName
+
+		candidates = append(candidates, s)
+		r.logger.Debug("discovered skill", "name", s.QualifiedName(), "path", s.Path)
+	}
+	return candidates, variants, invalid
+}
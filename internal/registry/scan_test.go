@@ -0,0 +1,186 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func writeSkills(t *testing.T, root string, n int) {
+	t.Helper()
+	for i := range n {
+		name := fmt.Sprintf("skill-%03d", i)
+		writeFile(t, filepath.Join(root, name, "SKILL.md"), "---\nname: "+name+"\ndescription: Skill "+name+"\n---\n\nInstructions.\n")
+	}
+}
+
+func TestScanContextWorkers(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSkills(t, tmpDir, 50)
+	writeFile(t, filepath.Join(tmpDir, "broken", "SKILL.md"), "no frontmatter\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	for _, workers := range []int{0, 1, 8, 100} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			reg := NewRegistry(tmpDir, logger, WithScanWorkers(workers))
+			if err := reg.ScanContext(context.Background()); err != nil {
+				t.Fatalf("ScanContext() error: %v", err)
+			}
+			if reg.Count() != 50 {
+				t.Errorf("Count() = %d, want 50", reg.Count())
+			}
+			if reg.Status().Errors != 1 {
+				t.Errorf("Status().Errors = %d, want 1", reg.Status().Errors)
+			}
+		})
+	}
+}
+
+func TestScanContextCancelled(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSkills(t, tmpDir, 3)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	writeSkills(t, tmpDir, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := reg.ScanContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ScanContext() error = %v, want context.Canceled", err)
+	}
+	if reg.Count() != 3 {
+		t.Errorf("Count() = %d, want the previous 3 skills kept", reg.Count())
+	}
+	if reg.Status().Ready() {
+		t.Error("registry should not be ready after an aborted scan")
+	}
+}
+
+func TestScanContextTimeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSkills(t, tmpDir, 3)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger, WithScanTimeout(1))
+
+	err := reg.Scan()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Scan() error = %v, want context.DeadlineExceeded", err)
+	}
+	if reg.Count() != 0 {
+		t.Errorf("Count() = %d, want 0", reg.Count())
+	}
+}
+
+func TestScanContextConcurrentReaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeSkills(t, tmpDir, 20)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 20 {
+				if err := reg.Scan(); err != nil {
+					t.Errorf("Scan() error: %v", err)
+				}
+			}
+		})
+		wg.Go(func() {
+			for range 200 {
+				if n := len(reg.List()); n != 20 {
+					t.Errorf("List() returned %d skills during a rescan, want 20", n)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestRegistryProblems(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "good", "SKILL.md"), "---\nname: good\ndescription: Good\n---\n\nFine.\n")
+	writeFile(t, filepath.Join(tmpDir, "good", "SKILL.ja.md"), "---\nname: other\ndescription: 別\n---\n\n別。\n")
+	writeFile(t, filepath.Join(tmpDir, "broken", "SKILL.md"), "no frontmatter\n")
+	writeFile(t, filepath.Join(tmpDir, "orphan", "SKILL.md"), "---\nname: orphan\nextends: missing\n---\n\nText.\n")
+	writeFile(t, filepath.Join(tmpDir, "lonely", "SKILL.fr.md"), "---\nname: lonely\ndescription: Seul\n---\n\nSeul.\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	want := []struct {
+		path string
+		err  error
+	}{
+		{filepath.Join(tmpDir, "broken", "SKILL.md"), ErrNoFrontmatter},
+		{filepath.Join(tmpDir, "good", "SKILL.ja.md"), ErrVariantNameMismatch},
+		{filepath.Join(tmpDir, "lonely", "SKILL.fr.md"), ErrVariantWithoutSkill},
+		{filepath.Join(tmpDir, "orphan", "SKILL.md"), ErrParentNotFound},
+	}
+	problems := reg.Problems()
+	if len(problems) != len(want) {
+		t.Fatalf("Problems() = %+v, want %d problems", problems, len(want))
+	}
+	for i, w := range want {
+		if problems[i].Path != w.path || !errors.Is(problems[i].Err, w.err) {
+			t.Errorf("Problems()[%d] = %s: %v, want %s: %v", i, problems[i].Path, problems[i].Err, w.path, w.err)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(tmpDir, "broken")); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if len(reg.Problems()) != len(want)-1 {
+		t.Errorf("Problems() after rescan = %+v, want %d", reg.Problems(), len(want)-1)
+	}
+}
+
+func TestRegistryFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile(t, filepath.Join(tmpDir, "good", "SKILL.md"), "---\nname: good\ndescription: Good\n---\n\nFine.\n")
+	writeFile(t, filepath.Join(tmpDir, "good", "SKILL.ja.md"), "---\nname: good\ndescription: 良い\n---\n\n良い。\n")
+	writeFile(t, filepath.Join(tmpDir, "good", "README.md"), "Not a skill.\n")
+	writeFile(t, filepath.Join(tmpDir, "broken", "SKILL.md"), "no frontmatter\n")
+	writeFile(t, filepath.Join(tmpDir, "lint.skill.md"), "---\nname: lint\ndescription: Lint\n---\n\nLint.\n")
+	writeFile(t, filepath.Join(tmpDir, "ignored", "SKILL.md"), "---\nname: ignored\ndescription: Ignored\n---\n\nIgnored.\n")
+	writeFile(t, filepath.Join(tmpDir, ".skillsignore"), "ignored/\n")
+
+	reg := NewRegistry(tmpDir, slog.New(slog.DiscardHandler))
+	files, err := reg.Files(context.Background())
+	if err != nil {
+		t.Fatalf("Files() error: %v", err)
+	}
+	want := []string{
+		filepath.Join(tmpDir, "broken", "SKILL.md"),
+		filepath.Join(tmpDir, "good", "SKILL.ja.md"),
+		filepath.Join(tmpDir, "good", "SKILL.md"),
+		filepath.Join(tmpDir, "lint.skill.md"),
+	}
+	if fmt.Sprint(files) != fmt.Sprint(want) {
+		t.Errorf("Files() = %v, want %v", files, want)
+	}
+}
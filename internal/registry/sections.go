@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// ParseSections builds a table of contents from the ATX headings in
+// markdown. Headings inside fenced code blocks are ignored.
+func ParseSections(markdown string) []skill.Section {
+	var (
+		sections []skill.Section
+		slugs    = make(map[string]int)
+		fence    string
+		offset   int
+	)
+
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		start := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		level, title, ok := parseHeading(line)
+		if !ok {
+			continue
+		}
+
+		slug := slugify(title)
+		if n := slugs[slug]; n > 0 {
+			slugs[slug] = n + 1
+			slug += "-" + strconv.Itoa(n)
+		} else {
+			slugs[slug] = 1
+		}
+
+		sections = append(sections, skill.Section{
+			Title: title,
+			Slug:  slug,
+			Level: level,
+			Start: start,
+		})
+	}
+
+	// A section ends where the next heading of the same or higher level begins.
+	for i := range sections {
+		sections[i].End = len(markdown)
+		for _, next := range sections[i+1:] {
+			if next.Level <= sections[i].Level {
+				sections[i].End = next.Start
+				break
+			}
+		}
+	}
+
+	return sections
+}
+
+// parseHeading parses an ATX heading line such as "## Title".
+func parseHeading(line string) (level int, title string, ok bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "    ") {
+		return 0, "", false
+	}
+	line = strings.TrimLeft(line, " ")
+
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	if level < len(line) && line[level] != ' ' && line[level] != '\t' {
+		return 0, "", false
+	}
+
+	title = strings.TrimSpace(line[level:])
+	title = strings.TrimSpace(strings.TrimRight(title, "#"))
+	if title == "" {
+		return 0, "", false
+	}
+	return level, title, true
+}
+
+// slugify converts a heading title to a GitHub-style anchor.
+func slugify(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func TestParseSections(t *testing.T) {
+	markdown := `# Title
+
+Intro text.
+
+## Setup
+
+Install things.
+
+### Details
+
+More details.
+
+## Usage
+
+` + "```bash\n# not a heading\n```" + `
+
+## Usage
+`
+
+	sections := ParseSections(markdown)
+
+	want := []struct {
+		title string
+		slug  string
+		level int
+	}{
+		{"Title", "title", 1},
+		{"Setup", "setup", 2},
+		{"Details", "details", 3},
+		{"Usage", "usage", 2},
+		{"Usage", "usage-1", 2},
+	}
+
+	if len(sections) != len(want) {
+		t.Fatalf("len(sections) = %d, want %d: %+v", len(sections), len(want), sections)
+	}
+	for i, w := range want {
+		if sections[i].Title != w.title || sections[i].Slug != w.slug || sections[i].Level != w.level {
+			t.Errorf("sections[%d] = {%q %q %d}, want {%q %q %d}",
+				i, sections[i].Title, sections[i].Slug, sections[i].Level, w.title, w.slug, w.level)
+		}
+	}
+
+	setup := sections[1].Content(markdown)
+	if setup != "## Setup\n\nInstall things.\n\n### Details\n\nMore details." {
+		t.Errorf("Setup content = %q", setup)
+	}
+
+	if sections[0].End != len(markdown) {
+		t.Errorf("Title section End = %d, want %d", sections[0].End, len(markdown))
+	}
+
+	sk := &skill.Skill{Instructions: markdown, Sections: sections}
+	if got := sk.Overview(); got != "# Title\n\nIntro text." {
+		t.Errorf("Overview() = %q", got)
+	}
+	if _, ok := sk.Section("DETAILS"); !ok {
+		t.Error("Section(DETAILS) should match title case-insensitively")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Review Process", "review-process"},
+		{"1. Understand the Context", "1-understand-the-context"},
+		{"Don't Panic!", "dont-panic"},
+		{"snake_case-ok", "snake_case-ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := slugify(tt.input); got != tt.want {
+				t.Errorf("slugify(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// MaxToolNameLength is the longest tool name MCP clients accept.
+const MaxToolNameLength = 128
+
+// Tool naming strategies.
+const (
+	// NamingSnake lowercases the skill name and joins words with underscores:
+	// "Code Review" becomes "code_review". It is the default.
+	NamingSnake = "snake"
+	// NamingKebab lowercases the skill name and joins words with hyphens:
+	// "Code Review" becomes "code-review".
+	NamingKebab = "kebab"
+	// NamingNamespaced prefixes the snake_case name with a namespace and a
+	// dot: "Code Review" becomes "skills.code_review".
+	NamingNamespaced = "namespaced"
+)
+
+// DefaultToolNamespace is the namespace used by NamingNamespaced when
+// none is configured.
+const DefaultToolNamespace = "skills"
+
+var (
+	// ErrInvalidToolName is returned when a tool name breaks the MCP naming rules.
+	ErrInvalidToolName = errors.New("invalid tool name")
+	// ErrUnknownNamingStrategy is returned for an unrecognized naming strategy.
+	ErrUnknownNamingStrategy = errors.New("unknown tool naming strategy")
+)
+
+// ToolNaming controls how skill names become MCP tool names.
+type ToolNaming struct {
+	// Strategy is NamingSnake (the default when empty), NamingKebab or
+	// NamingNamespaced.
+	Strategy string
+
+	// Namespace is the NamingNamespaced prefix. The default is
+	// DefaultToolNamespace.
+	Namespace string
+
+	// Prefix is prepended verbatim to every derived name, e.g. "skill_".
+	Prefix string
+}
+
+// Validate reports whether the naming configuration can produce valid names.
+func (n ToolNaming) Validate() error {
+	switch n.Strategy {
+	case "", NamingSnake, NamingKebab, NamingNamespaced:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownNamingStrategy, n.Strategy)
+	}
+	for _, r := range n.Prefix + n.Namespace {
+		if !validToolNameRune(r) {
+			return fmt.Errorf("%w: prefix and namespace may only contain A-Z, a-z, 0-9, '_', '-' and '.'", ErrInvalidToolName)
+		}
+	}
+	return nil
+}
+
+// ToolName returns the MCP tool name for s: its tool_name frontmatter
+// override verbatim if set, otherwise its name converted by the strategy
+// and preceded by its namespace, if any.
+// The result is validated with ValidateToolName.
+func (n ToolNaming) ToolName(s *skill.Skill) (string, error) {
+	if s.ToolName != "" {
+		return s.ToolName, ValidateToolName(s.ToolName)
+	}
+
+	sep := '_'
+	switch n.Strategy {
+	case "", NamingSnake, NamingNamespaced:
+	case NamingKebab:
+		sep = '-'
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownNamingStrategy, n.Strategy)
+	}
+
+	name := normalizeToolName(s.Name, sep)
+	if name == "" {
+		return "", fmt.Errorf("%w: %q has no characters usable in a tool name (set tool_name in the frontmatter)", ErrInvalidToolName, s.Name)
+	}
+	if ns := namespaceToolName(s.Namespace, sep); ns != "" {
+		name = ns + string(sep) + string(sep) + name
+	}
+	if n.Strategy == NamingNamespaced {
+		namespace := n.Namespace
+		if namespace == "" {
+			namespace = DefaultToolNamespace
+		}
+		name = namespace + "." + name
+	}
+	name = n.Prefix + name
+
+	if err := ValidateToolName(name); err != nil {
+		return "", fmt.Errorf("%w (set tool_name in the frontmatter)", err)
+	}
+	return name, nil
+}
+
+// namespaceToolName converts a dot-separated namespace to a tool name
+// prefix, joining its normalized segments with a doubled sep so they stay
+// distinguishable from words within a segment: "backend.api" becomes
+// "backend__api". Segments with no usable characters are dropped.
+func namespaceToolName(namespace string, sep rune) string {
+	var parts []string
+	for _, seg := range strings.Split(namespace, ".") {
+		if seg = normalizeToolName(seg, sep); seg != "" {
+			parts = append(parts, seg)
+		}
+	}
+	return strings.Join(parts, string(sep)+string(sep))
+}
+
+// ValidateToolName checks name against the MCP tool name rules: 1 to
+// MaxToolNameLength characters from A-Z, a-z, 0-9, '_', '-' and '.'.
+func ValidateToolName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidToolName)
+	}
+	if len(name) > MaxToolNameLength {
+		return fmt.Errorf("%w: %q is %d characters (max %d)", ErrInvalidToolName, name, len(name), MaxToolNameLength)
+	}
+	for _, r := range name {
+		if !validToolNameRune(r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidToolName, name, r)
+		}
+	}
+	return nil
+}
+
+// validToolNameRune reports whether r may appear in an MCP tool name.
+func validToolNameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '_' || r == '-' || r == '.'
+}
+
+// normalizeToolName lowercases name and replaces each run of characters
+// other than a-z and 0-9 with a single sep, trimming separators from the
+// ends. Collapsing runs keeps a doubled sep reserved for namespaces, so
+// "backend  testing" cannot collide with "backend" in namespace "testing".
+// Names made only of such characters, e.g. in a non-Latin script,
+// normalize to "".
+func normalizeToolName(name string, sep rune) string {
+	var sb strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if pending && sb.Len() > 0 {
+				sb.WriteRune(sep)
+			}
+			pending = false
+			sb.WriteRune(r)
+		} else {
+			pending = true
+		}
+	}
+	return sb.String()
+}
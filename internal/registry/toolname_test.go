@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func TestToolNamingToolName(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  ToolNaming
+		skill   skill.Skill
+		want    string
+		wantErr error
+	}{
+		{name: "snake by default", skill: skill.Skill{Name: "Code Review"}, want: "code_review"},
+		{name: "kebab", naming: ToolNaming{Strategy: NamingKebab}, skill: skill.Skill{Name: "Code Review"}, want: "code-review"},
+		{name: "kebab from snake", naming: ToolNaming{Strategy: NamingKebab}, skill: skill.Skill{Name: "git_workflow"}, want: "git-workflow"},
+		{name: "namespaced", naming: ToolNaming{Strategy: NamingNamespaced}, skill: skill.Skill{Name: "code-review"}, want: "skills.code_review"},
+		{name: "custom namespace", naming: ToolNaming{Strategy: NamingNamespaced, Namespace: "acme"}, skill: skill.Skill{Name: "code-review"}, want: "acme.code_review"},
+		{name: "namespace", skill: skill.Skill{Name: "testing", Namespace: "backend.api"}, want: "backend__api__testing"},
+		{name: "repeated separators", skill: skill.Skill{Name: "backend  testing"}, want: "backend_testing"},
+		{name: "repeated separators kebab", naming: ToolNaming{Strategy: NamingKebab}, skill: skill.Skill{Name: "backend -- testing"}, want: "backend-testing"},
+		{name: "kebab namespace", naming: ToolNaming{Strategy: NamingKebab}, skill: skill.Skill{Name: "testing", Namespace: "backend"}, want: "backend--testing"},
+		{name: "prefix", naming: ToolNaming{Prefix: "skill_"}, skill: skill.Skill{Name: "code-review"}, want: "skill_code_review"},
+		{name: "override", naming: ToolNaming{Prefix: "skill_"}, skill: skill.Skill{Name: "レビュー", ToolName: "review"}, want: "review"},
+		{name: "invalid override", skill: skill.Skill{Name: "review", ToolName: "code review"}, wantErr: ErrInvalidToolName},
+		{name: "non-latin name", skill: skill.Skill{Name: "レビュー"}, wantErr: ErrInvalidToolName},
+		{name: "too long", skill: skill.Skill{Name: strings.Repeat("a", MaxToolNameLength+1)}, wantErr: ErrInvalidToolName},
+		{name: "unknown strategy", naming: ToolNaming{Strategy: "camel"}, skill: skill.Skill{Name: "review"}, wantErr: ErrUnknownNamingStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.naming.ToolName(&tt.skill)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ToolName() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToolName() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToolName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolNamingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  ToolNaming
+		wantErr error
+	}{
+		{name: "zero value", naming: ToolNaming{}},
+		{name: "kebab with prefix", naming: ToolNaming{Strategy: NamingKebab, Prefix: "skill-"}},
+		{name: "unknown strategy", naming: ToolNaming{Strategy: "camel"}, wantErr: ErrUnknownNamingStrategy},
+		{name: "invalid prefix", naming: ToolNaming{Prefix: "skill:"}, wantErr: ErrInvalidToolName},
+		{name: "invalid namespace", naming: ToolNaming{Strategy: NamingNamespaced, Namespace: "my skills"}, wantErr: ErrInvalidToolName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.naming.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryToolNaming(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tmpDir, "review", "SKILL.md"), "---\nname: code-review\ndescription: Review code\n---\n\nReview.\n")
+	writeFile(t, filepath.Join(tmpDir, "japanese", "SKILL.md"), "---\nname: レビュー\ndescription: Review in Japanese\ntool_name: review_ja\n---\n\nレビュー。\n")
+	writeFile(t, filepath.Join(tmpDir, "invalid", "SKILL.md"), "---\nname: 翻訳\ndescription: Translate\n---\n\n翻訳。\n")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := NewRegistry(tmpDir, logger, WithToolNaming(ToolNaming{Prefix: "skill_"}))
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	if got := reg.Get("code-review"); got == nil || got.Tool != "skill_code_review" {
+		t.Errorf("code-review Tool = %v, want skill_code_review", got)
+	}
+	if got := reg.Get("レビュー"); got == nil || got.Tool != "review_ja" {
+		t.Errorf("レビュー Tool = %v, want review_ja", got)
+	}
+	if reg.Get("翻訳") != nil {
+		t.Error("skill without a valid tool name should not be registered")
+	}
+	if got := reg.Status().Errors; got != 1 {
+		t.Errorf("Status().Errors = %d, want 1", got)
+	}
+}
@@ -0,0 +1,339 @@
+// Package scaffold creates new skill directories from templates.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidName is returned for a skill name that cannot name a skill
+	// directory or tool.
+	ErrInvalidName = errors.New("invalid skill name")
+
+	// ErrExists is returned when the skill directory already exists.
+	ErrExists = errors.New("skill directory already exists")
+
+	// ErrTemplateNotFound is returned when no templates directory has the
+	// requested template.
+	ErrTemplateNotFound = errors.New("template not found")
+
+	// ErrInvalidTemplate is returned when a template does not produce a
+	// valid SKILL.md for the new skill.
+	ErrInvalidTemplate = errors.New("invalid template")
+)
+
+// DefaultDescription is the description given to new skills when none is
+// provided.
+const DefaultDescription = "TODO: Describe what this skill does and when to use it."
+
+// skillFileName is the skill file every template must provide.
+const skillFileName = "SKILL.md"
+
+// defaultTemplate is the SKILL.md of skills created without a template.
+const defaultTemplate = `---
+name: {{yaml .Name}}
+description: {{yaml .Description}}
+---
+
+# {{.Title}}
+
+TODO: Explain what the model should do when this skill is called.
+
+## Guidelines
+
+- TODO: Rules and best practices to follow
+`
+
+// Data is the data SKILL.md templates are executed with.
+type Data struct {
+	// Name is the skill name.
+	Name string
+
+	// Description is the skill description.
+	Description string
+
+	// Title is the name as a heading, e.g. "Code Review" for "code-review".
+	Title string
+
+	// ToolName is the skill's default snake_case tool name.
+	ToolName string
+}
+
+// Options configures a new skill.
+type Options struct {
+	// Name is the skill name, also used as its directory name.
+	Name string
+
+	// Description is the skill description. The default is
+	// DefaultDescription.
+	Description string
+
+	// Template is the path of a template directory, or "" for the
+	// built-in template. Its SKILL.md is executed as a text/template with
+	// Data; every other file is copied as is.
+	Template string
+
+	// Scripts adds an empty scripts/ directory for helper scripts.
+	Scripts bool
+
+	// References adds an empty references/ directory for reference
+	// documents.
+	References bool
+}
+
+// ValidateName checks that name can name a skill directory and derives a
+// non-empty tool name.
+func ValidateName(name string) error {
+	switch {
+	case strings.TrimSpace(name) != name || name == "":
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	case strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "."):
+		return fmt.Errorf("%w: %q must not contain path separators or start with a dot", ErrInvalidName, name)
+	case registry.ToolNameForSkill(name) == "":
+		return fmt.Errorf("%w: %q has no characters usable in a tool name", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Collision returns the file of a skill scanned by reg, registered or
+// shadowed, whose tool name a new skill called name would collide with, or
+// "" if there is none. A collision is a skill whose registered tool name,
+// which reflects any tool_name override, naming strategy and prefix, or
+// whose default snake_case tool name, matches either of the new skill's.
+func Collision(reg *registry.Registry, name string, naming registry.ToolNaming) string {
+	want := map[string]bool{registry.ToolNameForSkill(name): true}
+	if tool, err := naming.ToolName(&skill.Skill{Name: name}); err == nil {
+		want[tool] = true
+	}
+	collides := func(s *skill.Skill) bool {
+		return want[s.Tool] || want[registry.ToolNameForSkill(s.Name)]
+	}
+
+	for _, s := range reg.List() {
+		if collides(s) {
+			return s.File
+		}
+	}
+	for _, c := range reg.Conflicts() {
+		for _, s := range c.Candidates {
+			if collides(s) {
+				return s.File
+			}
+		}
+	}
+	return ""
+}
+
+// Create creates the skill directory parent/<name> from opts. Nothing is
+// left behind if it fails.
+func Create(parent string, opts Options) (string, error) {
+	if err := ValidateName(opts.Name); err != nil {
+		return "", err
+	}
+	if opts.Description == "" {
+		opts.Description = DefaultDescription
+	}
+
+	dir := filepath.Join(parent, opts.Name)
+	if _, err := os.Lstat(dir); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrExists, dir)
+	}
+
+	skillMD, err := render(opts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return "", fmt.Errorf("create skills directory: %w", err)
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create skill directory: %w", err)
+	}
+	if err := populate(dir, opts, skillMD); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// render executes the template's SKILL.md and checks that the result is a
+// valid skill with the requested name.
+func render(opts Options) ([]byte, error) {
+	source := defaultTemplate
+	if opts.Template != "" {
+		data, err := os.ReadFile(filepath.Join(opts.Template, skillFileName))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, opts.Template, err)
+		}
+		source = string(data)
+	}
+
+	tmpl, err := template.New(skillFileName).Funcs(template.FuncMap{"yaml": yamlString}).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, Data{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Title:       title(opts.Name),
+		ToolName:    registry.ToolNameForSkill(opts.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	s, err := registry.ParseSkillMDBytes(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w: SKILL.md: %v", ErrInvalidTemplate, err)
+	}
+	if s.Name != opts.Name {
+		return nil, fmt.Errorf("%w: SKILL.md names the skill %q, not %q (use {{yaml .Name}})", ErrInvalidTemplate, s.Name, opts.Name)
+	}
+	return buf.Bytes(), nil
+}
+
+// populate writes the skill's files into dir.
+func populate(dir string, opts Options, skillMD []byte) error {
+	if opts.Template != "" {
+		if err := copyTemplate(opts.Template, dir); err != nil {
+			return fmt.Errorf("copy template: %w", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, skillFileName), skillMD, 0o644); err != nil {
+		return fmt.Errorf("write SKILL.md: %w", err)
+	}
+
+	var skeletons []string
+	if opts.Scripts {
+		skeletons = append(skeletons, "scripts")
+	}
+	if opts.References {
+		skeletons = append(skeletons, "references")
+	}
+	for _, name := range skeletons {
+		sub := filepath.Join(dir, name)
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", name, err)
+		}
+		// Keep an empty directory in version control until it has content.
+		if entries, err := os.ReadDir(sub); err == nil && len(entries) == 0 {
+			if err := os.WriteFile(filepath.Join(sub, ".gitkeep"), nil, 0o644); err != nil {
+				return fmt.Errorf("create %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// copyTemplate copies every file of the template directory src except its
+// SKILL.md into dst, preserving file modes so scripts stay executable.
+func copyTemplate(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case rel == ".":
+			return nil
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case rel == skillFileName || !d.Type().IsRegular():
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// FindTemplate returns the path of the named template in the first of
+// dirs that has it. A template is a directory containing a SKILL.md.
+func FindTemplate(dirs []string, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(filepath.Join(path, skillFileName)); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+	available := Templates(dirs)
+	if len(available) == 0 {
+		return "", fmt.Errorf("%w: %q (no templates in %s)", ErrTemplateNotFound, name, strings.Join(dirs, ", "))
+	}
+	return "", fmt.Errorf("%w: %q (available: %s)", ErrTemplateNotFound, name, strings.Join(available, ", "))
+}
+
+// Templates returns the names of the templates in dirs, sorted. A name in
+// several directories is listed once.
+func Templates(dirs []string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() || seen[e.Name()] || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, e.Name(), skillFileName)); err == nil {
+				seen[e.Name()] = true
+				names = append(names, e.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// title converts a skill name to a heading: "code-review" becomes
+// "Code Review".
+func title(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == ' ' })
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
+
+// yamlString renders s as a YAML scalar, quoted only where needed, for
+// use in template frontmatter.
+func yamlString(s string) (string, error) {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
@@ -0,0 +1,171 @@
+package scaffold
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateDefault(t *testing.T) {
+	root := t.TempDir()
+
+	dir, err := Create(root, Options{Name: "api-design", Description: "Design APIs: resources", Scripts: true, References: true})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if dir != filepath.Join(root, "api-design") {
+		t.Errorf("Create() = %s", dir)
+	}
+
+	s, err := registry.ParseSkillMD(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		t.Fatalf("created SKILL.md is invalid: %v", err)
+	}
+	if s.Name != "api-design" || s.Description != "Design APIs: resources" {
+		t.Errorf("skill = %q: %q", s.Name, s.Description)
+	}
+	if !strings.HasPrefix(s.Instructions, "# Api Design\n") {
+		t.Errorf("Instructions = %q", s.Instructions)
+	}
+	for _, keep := range []string{"scripts/.gitkeep", "references/.gitkeep"} {
+		if _, err := os.Stat(filepath.Join(dir, keep)); err != nil {
+			t.Errorf("missing %s: %v", keep, err)
+		}
+	}
+
+	if _, err := Create(root, Options{Name: "api-design"}); !errors.Is(err, ErrExists) {
+		t.Errorf("Create() again error = %v, want ErrExists", err)
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	templates := t.TempDir()
+	writeFile(t, filepath.Join(templates, "runbook", "SKILL.md"),
+		"---\nname: {{yaml .Name}}\ndescription: {{yaml .Description}}\n---\n\n# {{.Title}}\n\nCall {{.ToolName}}.\n", 0644)
+	writeFile(t, filepath.Join(templates, "runbook", "scripts", "run.sh"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(templates, "fixed", "SKILL.md"), "---\nname: fixed\ndescription: Fixed\n---\n", 0644)
+	writeFile(t, filepath.Join(templates, "notes.txt"), "not a template", 0644)
+
+	if got := strings.Join(Templates([]string{templates, filepath.Join(templates, "missing")}), ","); got != "fixed,runbook" {
+		t.Errorf("Templates() = %s, want fixed,runbook", got)
+	}
+
+	tmpl, err := FindTemplate([]string{filepath.Join(templates, "missing"), templates}, "runbook")
+	if err != nil {
+		t.Fatalf("FindTemplate() error: %v", err)
+	}
+
+	root := t.TempDir()
+	dir, err := Create(root, Options{Name: "db-restore", Template: tmpl, Scripts: true})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	s, err := registry.ParseSkillMD(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		t.Fatalf("created SKILL.md is invalid: %v", err)
+	}
+	if s.Instructions != "# Db Restore\n\nCall db_restore." || s.Description != DefaultDescription {
+		t.Errorf("skill = %q: %q", s.Description, s.Instructions)
+	}
+	info, err := os.Stat(filepath.Join(dir, "scripts", "run.sh"))
+	if err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("scripts/run.sh not copied executable: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scripts", ".gitkeep")); err == nil {
+		t.Error("scripts/ from the template should not get a .gitkeep")
+	}
+
+	fixed, _ := FindTemplate([]string{templates}, "fixed")
+	if _, err := Create(root, Options{Name: "other", Template: fixed}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("Create() with a fixed name error = %v, want ErrInvalidTemplate", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "other")); err == nil {
+		t.Error("failed Create() left a directory behind")
+	}
+
+	if _, err := FindTemplate([]string{templates}, "missing"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("FindTemplate(missing) error = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"code-review", true},
+		{"Code Review", true},
+		{"", false},
+		{" padded", false},
+		{"a/b", false},
+		{".hidden", false},
+		{"日本語", false},
+	}
+	for _, tt := range tests {
+		err := ValidateName(tt.name)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateName(%q) error: %v", tt.name, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidName) {
+			t.Errorf("ValidateName(%q) error = %v, want ErrInvalidName", tt.name, err)
+		}
+	}
+}
+
+func TestCollision(t *testing.T) {
+	root := t.TempDir()
+	release := filepath.Join(root, "release", "SKILL.md")
+	review := filepath.Join(root, "code-review", "SKILL.md")
+	writeFile(t, release, "---\nname: release\ndescription: Release\ntool_name: deploy\n---\n\nShip it.\n", 0644)
+	writeFile(t, review, "---\nname: code-review\ndescription: Review\n---\n\nReview.\n", 0644)
+
+	tests := []struct {
+		name   string
+		naming registry.ToolNaming
+		want   string
+	}{
+		{name: "deploy", want: release},
+		{name: "Code_Review", want: review},
+		{name: "code-review", naming: registry.ToolNaming{Strategy: registry.NamingKebab}, want: review},
+		{name: "skill_lint", naming: registry.ToolNaming{Prefix: "skill_"}},
+		{name: "lint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := registry.NewRegistry(root, slog.New(slog.DiscardHandler), registry.WithToolNaming(tt.naming))
+			if err := reg.Scan(); err != nil {
+				t.Fatal(err)
+			}
+			if got := Collision(reg, tt.name, tt.naming); got != tt.want {
+				t.Errorf("Collision(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// With a prefix, a new skill collides with a registered tool name it
+	// would take, not only with the default one.
+	writeFile(t, filepath.Join(root, "skill-lint", "SKILL.md"), "---\nname: lint-rules\ndescription: Lint\ntool_name: skill_lint\n---\n\nLint.\n", 0644)
+	naming := registry.ToolNaming{Prefix: "skill_"}
+	reg := registry.NewRegistry(root, slog.New(slog.DiscardHandler), registry.WithToolNaming(naming))
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	if got := Collision(reg, "lint", naming); got != filepath.Join(root, "skill-lint", "SKILL.md") {
+		t.Errorf("Collision(lint) with prefix = %q", got)
+	}
+}
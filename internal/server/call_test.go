@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func TestShowMatchesCall(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "guide", "# Guide\n\nRead this first.\n\n## Setup\n\nInstall the tools.\n\n## Usage\n\nRun it.")
+	variant := "---\nname: guide\ndescription: ガイド\n---\n\nまずこれを読む。\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "guide", "SKILL.ja.md"), []byte(variant), 0644); err != nil {
+		t.Fatalf("failed to write variant: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv := New(reg, logger, WithSectionThreshold(0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name  string
+		input SkillInput
+		args  map[string]any
+	}{
+		{name: "overview"},
+		{name: "section", input: SkillInput{Section: "setup"}, args: map[string]any{"section": "setup"}},
+		{name: "locale", input: SkillInput{Locale: "ja"}, args: map[string]any{"locale": "ja"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, output, err := srv.Show("guide", tt.input)
+			if err != nil {
+				t.Fatalf("Show() error: %v", err)
+			}
+
+			result, err := srv.Call(ctx, "guide", tt.args)
+			if err != nil {
+				t.Fatalf("Call() error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("Call() returned a tool error: %v", result.Content)
+			}
+			if got := result.Content[0].(*mcp.TextContent).Text; got != text {
+				t.Errorf("Show() text = %q, call returned %q", text, got)
+			}
+
+			// Compare the structured output as it appears on the wire.
+			var want, got any
+			data, _ := json.Marshal(output)
+			json.Unmarshal(data, &want)
+			data, _ = json.Marshal(result.StructuredContent)
+			json.Unmarshal(data, &got)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Show() output = %v, call returned %v", want, got)
+			}
+		})
+	}
+}
+
+func TestShowAndCallErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "code-review", "Review the code.")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv := New(reg, logger)
+
+	if _, _, err := srv.Show("code_review", SkillInput{}); err != nil {
+		t.Errorf("Show() by tool name error: %v", err)
+	}
+	if _, _, err := srv.Show("missing", SkillInput{}); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("Show(missing) error = %v, want ErrUnknownSkill", err)
+	}
+	if _, _, err := srv.Show("code-review", SkillInput{Section: "nope"}); !errors.Is(err, errUnknownSection) {
+		t.Errorf("Show() unknown section error = %v, want errUnknownSection", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := srv.Call(ctx, "code_review", map[string]any{"section": "nope"})
+	if err != nil {
+		t.Fatalf("Call() error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Call() with an unknown section should return a tool error")
+	}
+
+	if _, err := srv.Call(ctx, "missing", nil); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Call(missing) error = %v, want an unknown tool error", err)
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MCPPath is the path the streamable HTTP MCP endpoint is served on.
+const MCPPath = "/mcp"
+
+// shutdownTimeout bounds how long RunHTTP waits for in-flight requests.
+const shutdownTimeout = 5 * time.Second
+
+// HTTPHandler returns a handler serving the MCP streamable HTTP transport on
+// MCPPath alongside operational endpoints:
+//
+//   - /metrics: Prometheus metrics
+//   - /healthz: liveness, always 200 while the process is serving
+//   - /readyz: 200 once the initial scan has finished and the last scan succeeded, 503 otherwise
+func (s *Server) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle(MCPPath, mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return s.mcp
+	}, &mcp.StreamableHTTPOptions{Logger: s.logger}))
+	mux.Handle("GET /metrics", promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("GET /readyz", s.handleReady)
+
+	return mux
+}
+
+// handleReady reports readiness based on the registry's scan status.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	status := s.registry.Status()
+	switch {
+	case !status.Scanned:
+		http.Error(w, "initial scan in progress", http.StatusServiceUnavailable)
+	case status.LastError != nil:
+		http.Error(w, fmt.Sprintf("last scan failed: %v", status.LastError), http.StatusServiceUnavailable)
+	default:
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// RunHTTP serves HTTPHandler on addr until ctx is cancelled.
+func (s *Server) RunHTTP(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves HTTPHandler on ln until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	httpServer := &http.Server{
+		Handler:           s.HTTPHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.logger.Info("starting skills MCP server",
+		"transport", "http",
+		"addr", ln.Addr().String(),
+		"skills_count", s.registry.Count(),
+		"skills_root", s.registry.Root(),
+	)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeTestSkill(t *testing.T, root, name, instructions string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create skill dir: %v", err)
+	}
+	content := "---\nname: " + name + "\ndescription: The " + name + " skill\n---\n\n" + instructions + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body error: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestHTTPHandlerReadiness(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "greet", "Say hello.")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	srv := New(reg, logger)
+
+	ts := httptest.NewServer(srv.HTTPHandler())
+	defer ts.Close()
+
+	if code, _ := get(t, ts.URL+"/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz status = %d, want 200", code)
+	}
+	if code, _ := get(t, ts.URL+"/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz before scan status = %d, want 503", code)
+	}
+
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv.Sync()
+
+	if code, _ := get(t, ts.URL+"/readyz"); code != http.StatusOK {
+		t.Errorf("/readyz after scan status = %d, want 200", code)
+	}
+
+	// A failed reload makes the server not ready but keeps serving skills.
+	if err := os.RemoveAll(tmpDir); err != nil {
+		t.Fatalf("failed to remove root: %v", err)
+	}
+	if err := reg.Scan(); err == nil {
+		t.Fatal("Scan() of missing root should fail")
+	}
+	if code, body := get(t, ts.URL+"/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz after failed reload status = %d, want 503 (%s)", code, body)
+	}
+	if reg.Count() != 1 {
+		t.Errorf("Count() after failed reload = %d, want 1", reg.Count())
+	}
+}
+
+func TestHTTPHandlerMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "greet", "Say hello.")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv := New(reg, logger)
+
+	ts := httptest.NewServer(srv.HTTPHandler())
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: ts.URL + MCPPath}, nil)
+	if err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer session.Close()
+
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "greet"}); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "missing"}); err == nil {
+		t.Error("CallTool(missing) should fail")
+	}
+
+	code, body := get(t, ts.URL+"/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("/metrics status = %d, want 200", code)
+	}
+	for _, want := range []string{
+		"skills_loaded 1",
+		"skills_scan_errors_total 0",
+		"skills_active_sessions 1",
+		`skills_tool_calls_total{skill="greet",status="ok"} 1`,
+		`skills_tool_calls_total{skill="unknown",status="error"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics missing %q", want)
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "alpha", "Alpha instructions.")
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv := New(reg, logger)
+
+	writeTestSkill(t, tmpDir, "beta", "Beta instructions.")
+	if err := os.RemoveAll(filepath.Join(tmpDir, "alpha")); err != nil {
+		t.Fatalf("failed to remove alpha: %v", err)
+	}
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	srv.Sync()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		srv.RunWithTransport(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error: %v", err)
+	}
+	if len(tools.Tools) != 1 || tools.Tools[0].Name != "beta" {
+		var names []string
+		for _, tool := range tools.Tools {
+			names = append(names, tool.Name)
+		}
+		t.Errorf("tools after Sync = %v, want [beta]", names)
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import "github.com/modelcontextprotocol/go-sdk/mcp"
+
+// metaLocaleKey is the _meta key clients use to declare their locale, on a
+// single tool call or for the whole session at initialization.
+const metaLocaleKey = "locale"
+
+// requestLocale returns the locale a tool call should be served in: the
+// call's locale argument, else the locale in the call's _meta, else the
+// locale the client declared at initialization, else the server default.
+func (s *Server) requestLocale(req *mcp.CallToolRequest, input SkillInput) string {
+	if input.Locale != "" {
+		return input.Locale
+	}
+	if req != nil && req.Params != nil {
+		if locale := metaLocale(req.Params.Meta); locale != "" {
+			return locale
+		}
+	}
+	if req != nil && req.Session != nil {
+		if params := req.Session.InitializeParams(); params != nil {
+			if locale := metaLocale(params.Meta); locale != "" {
+				return locale
+			}
+		}
+	}
+	return s.locale
+}
+
+// metaLocale returns the locale declared in meta, if any.
+func metaLocale(meta mcp.Meta) string {
+	locale, _ := meta[metaLocaleKey].(string)
+	return locale
+}
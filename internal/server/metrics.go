@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Tool call statuses reported in the skills_tool_calls_total metric.
+const (
+	callStatusOK    = "ok"
+	callStatusError = "error"
+)
+
+// unknownSkill labels calls to tools that are not registered, keeping label
+// cardinality bounded regardless of what clients send.
+const unknownSkill = "unknown"
+
+// metrics holds the Prometheus collectors exposed on /metrics in HTTP mode.
+type metrics struct {
+	registry *prometheus.Registry
+	calls    *prometheus.CounterVec
+}
+
+// newMetrics creates the collectors for s. Values that already live in the
+// registry or the MCP server are read at scrape time rather than duplicated.
+func newMetrics(s *Server) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "skills_tool_calls_total",
+			Help: "Number of skill tool calls by skill and status.",
+		}, []string{"skill", "status"}),
+	}
+
+	m.registry.MustRegister(
+		m.calls,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "skills_loaded",
+			Help: "Number of skills currently registered.",
+		}, func() float64 {
+			return float64(s.registry.Count())
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "skills_scan_errors_total",
+			Help: "Number of failed scans and unparseable SKILL.md files.",
+		}, func() float64 {
+			return float64(s.registry.Status().Errors)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "skills_active_sessions",
+			Help: "Number of connected MCP sessions.",
+		}, func() float64 {
+			var n int
+			for range s.mcp.Sessions() {
+				n++
+			}
+			return float64(n)
+		}),
+	)
+
+	return m
+}
+
+// middleware counts tools/call requests by skill and outcome. Counting here
+// rather than in the tool handler also captures calls the SDK rejects, such
+// as unknown tools or invalid arguments.
+func (m *metrics) middleware(s *Server) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if method != "tools/call" {
+				return result, err
+			}
+
+			skillName := unknownSkill
+			if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+				if name, ok := s.skillForTool(params.Name); ok {
+					skillName = name
+				}
+			}
+
+			status := callStatusOK
+			if res, ok := result.(*mcp.CallToolResult); err != nil || (ok && res.IsError) {
+				status = callStatusError
+			}
+			m.calls.WithLabelValues(skillName, status).Inc()
+
+			return result, err
+		}
+	}
+}
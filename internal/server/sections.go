@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// errUnknownSection is returned when a tool call names a section the skill lacks.
+var errUnknownSection = errors.New("unknown section")
+
+// sectioned reports whether sk should be returned as a table of contents
+// and overview rather than in full.
+func (s *Server) sectioned(sk *skill.Skill) bool {
+	if s.sectionThreshold < 0 || sk.Tokens <= s.sectionThreshold {
+		return false
+	}
+	return sk.Overview() != sk.Instructions
+}
+
+// tableOfContents returns the sections of sk as output entries.
+func tableOfContents(sk *skill.Skill) []SectionInfo {
+	toc := make([]SectionInfo, 0, len(sk.Sections))
+	for _, sec := range sk.Sections {
+		toc = append(toc, SectionInfo{Slug: sec.Slug, Title: sec.Title, Level: sec.Level})
+	}
+	return toc
+}
+
+// sectionSlugs returns the slugs of all sections of sk.
+func sectionSlugs(sk *skill.Skill) []string {
+	slugs := make([]string, 0, len(sk.Sections))
+	for _, sec := range sk.Sections {
+		slugs = append(slugs, sec.Slug)
+	}
+	return slugs
+}
+
+// formatOverviewResponse formats a skill's overview and table of contents.
+func formatOverviewResponse(sk *skill.Skill) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Skill: %s\n\n", sk.Name))
+	sb.WriteString(fmt.Sprintf("**Description:** %s\n\n", sk.Description))
+	sb.WriteString("---\n\n")
+	if overview := sk.Overview(); overview != "" {
+		sb.WriteString(overview)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("## Contents\n\n")
+	minLevel := 6
+	for _, sec := range sk.Sections {
+		minLevel = min(minLevel, sec.Level)
+	}
+	for _, sec := range sk.Sections {
+		indent := strings.Repeat("  ", sec.Level-minLevel)
+		sb.WriteString(fmt.Sprintf("%s- %s (`%s`)\n", indent, sec.Title, sec.Slug))
+	}
+	sb.WriteString("\nThis skill is long, so only its overview is shown. ")
+	sb.WriteString("Call this tool again with `section` set to a slug above to read that section.")
+
+	return sb.String()
+}
+
+// formatSectionResponse formats a single section of a skill.
+func formatSectionResponse(sk *skill.Skill, sec skill.Section) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Skill: %s\n\n", sk.Name))
+	sb.WriteString(fmt.Sprintf("**Section:** %s\n\n", sec.Title))
+	sb.WriteString("---\n\n")
+	sb.WriteString(sec.Content(sk.Instructions))
+
+	return sb.String()
+}
@@ -3,24 +3,65 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/telemetry"
 	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrUnknownSkill is returned by Show for a name that matches no skill or
+// tool.
+var ErrUnknownSkill = errors.New("unknown skill")
+
 // Server wraps an MCP server that exposes skills as tools.
 type Server struct {
 	mcp      *mcp.Server
 	registry *registry.Registry
+	metrics  *metrics
+	tools    map[string]string // maps registered tool name -> skill name
+	mu       sync.Mutex
 	logger   *slog.Logger
+
+	sectionThreshold int
+	locale           string
+}
+
+// DefaultSectionThreshold is the token count above which skills are
+// returned as a table of contents and overview instead of in full.
+const DefaultSectionThreshold = 2000
+
+// Option configures optional Server behavior.
+type Option func(*Server)
+
+// WithSectionThreshold sets the token count above which a skill with
+// sections is returned as a table of contents plus its overview, leaving
+// the model to fetch individual sections. Zero sections every skill that
+// has headings; a negative value always returns skills in full.
+func WithSectionThreshold(tokens int) Option {
+	return func(s *Server) {
+		s.sectionThreshold = tokens
+	}
+}
+
+// WithLocale sets the locale served when neither the call nor the client
+// selects one. Skills without a matching SKILL.<lang>.md variant fall back
+// to their default SKILL.md.
+func WithLocale(locale string) Option {
+	return func(s *Server) {
+		s.locale = locale
+	}
 }
 
 // New creates a new skills MCP server.
-func New(reg *registry.Registry, logger *slog.Logger) *Server {
+func New(reg *registry.Registry, logger *slog.Logger, opts ...Option) *Server {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -40,62 +81,175 @@ func New(reg *registry.Registry, logger *slog.Logger) *Server {
 	s := &Server{
 		mcp:      mcpServer,
 		registry: reg,
+		tools:    make(map[string]string),
 		logger:   logger,
+
+		sectionThreshold: DefaultSectionThreshold,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.metrics = newMetrics(s)
+	mcpServer.AddReceivingMiddleware(s.metrics.middleware(s))
 
-	s.registerSkillTools()
+	s.Sync()
 
 	return s
 }
 
-// registerSkillTools registers each skill as an MCP tool.
-func (s *Server) registerSkillTools() {
+// Sync reconciles the registered tools with the registry's current skills.
+// New skills are registered, existing ones are replaced with their latest
+// content, and tools whose skill disappeared are removed. Call it after
+// rescanning the registry.
+func (s *Server) Sync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make(map[string]string)
 	for _, sk := range s.registry.List() {
-		s.registerSkillTool(sk)
+		current[s.registerSkillTool(sk)] = sk.QualifiedName()
+	}
+
+	var stale []string
+	for name := range s.tools {
+		if _, ok := current[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) > 0 {
+		s.mcp.RemoveTools(stale...)
+		s.logger.Debug("removed skill tools", "names", stale)
 	}
+	s.tools = current
 }
 
-// SkillInput is the input type for skill tools (empty, no arguments needed).
-type SkillInput struct{}
+// SkillInput is the input type for skill tools.
+type SkillInput struct {
+	Section string `json:"section,omitempty" jsonschema:"Heading slug or title of a single section to read. Omit to read the skill."`
+	Locale  string `json:"locale,omitempty" jsonschema:"Language tag of the localized variant to read, e.g. ja or pt-BR. Omit to use the client's locale."`
+}
 
 // SkillOutput is the output type for skill tools.
 type SkillOutput struct {
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Instructions string `json:"instructions"`
-	Path         string `json:"path"`
+	Name         string        `json:"name"`
+	Namespace    string        `json:"namespace,omitempty"`
+	Description  string        `json:"description"`
+	Instructions string        `json:"instructions"`
+	Path         string        `json:"path"`
+	Locale       string        `json:"locale,omitempty"`
+	Section      string        `json:"section,omitempty"`
+	Sections     []SectionInfo `json:"sections,omitempty"`
+}
+
+// SectionInfo is a table of contents entry in SkillOutput.
+type SectionInfo struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	Level int    `json:"level"`
 }
 
-// registerSkillTool registers a single skill as an MCP tool.
-func (s *Server) registerSkillTool(sk *skill.Skill) {
-	toolName := registry.ToolNameForSkill(sk.Name)
+// registerSkillTool registers a single skill as an MCP tool and returns its tool name.
+func (s *Server) registerSkillTool(sk *skill.Skill) string {
+	toolName := sk.Tool
 
 	tool := &mcp.Tool{
 		Name:        toolName,
 		Description: sk.Description,
+		Meta: mcp.Meta{
+			"tokens": sk.Tokens,
+		},
+	}
+	if len(sk.Variants) > 0 {
+		tool.Meta["locales"] = sk.Locales()
 	}
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SkillInput) (*mcp.CallToolResult, SkillOutput, error) {
-		output := SkillOutput{
-			Name:         sk.Name,
-			Description:  sk.Description,
-			Instructions: sk.Instructions,
-			Path:         sk.Path,
+		ctx, span := telemetry.Tracer().Start(ctx, "skills.call "+toolName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				telemetry.AttrToolName.String(toolName),
+				telemetry.AttrSkillName.String(sk.QualifiedName()),
+			),
+		)
+		defer span.End()
+
+		sk := sk.Localize(s.requestLocale(req, input))
+		if sk.Locale != "" {
+			span.SetAttributes(attribute.String("skill.locale", sk.Locale))
+		}
+
+		text, output, err := s.respond(sk, input)
+		if err != nil {
+			span.RecordError(err)
+			return nil, SkillOutput{}, err
 		}
 
 		result := &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: formatSkillResponse(sk),
+					Text: text,
 				},
 			},
 		}
 
+		telemetry.RecordInvocation(ctx, sk.QualifiedName(), len(text))
+		span.SetAttributes(attribute.Int("skill.response_bytes", len(text)))
+
 		return result, output, nil
 	}
 
 	mcp.AddTool(s.mcp, tool, handler)
-	s.logger.Debug("registered skill tool", "name", toolName, "skill", sk.Name)
+	s.logger.Debug("registered skill tool", "name", toolName, "skill", sk.QualifiedName())
+	return toolName
+}
+
+// respond returns the text and structured output of a call to sk's tool,
+// where sk has already been localized for the call.
+func (s *Server) respond(sk *skill.Skill, input SkillInput) (string, SkillOutput, error) {
+	output := SkillOutput{
+		Name:        sk.Name,
+		Namespace:   sk.Namespace,
+		Description: sk.Description,
+		Path:        sk.Path,
+		Locale:      sk.Locale,
+	}
+
+	var text string
+	switch {
+	case input.Section != "":
+		sec, ok := sk.Section(input.Section)
+		if !ok {
+			return "", SkillOutput{}, fmt.Errorf("%w %q; available sections: %s",
+				errUnknownSection, input.Section, strings.Join(sectionSlugs(sk), ", "))
+		}
+		output.Section = sec.Slug
+		output.Instructions = sec.Content(sk.Instructions)
+		text = formatSectionResponse(sk, sec)
+	case s.sectioned(sk):
+		output.Instructions = sk.Overview()
+		output.Sections = tableOfContents(sk)
+		text = formatOverviewResponse(sk)
+	default:
+		output.Instructions = sk.Instructions
+		text = formatSkillResponse(sk)
+	}
+	return text, output, nil
+}
+
+// Show returns the text and structured output that calling the tool of the
+// skill with the given qualified name or tool name returns for input,
+// without a client. The locale is input's, else the server default.
+func (s *Server) Show(name string, input SkillInput) (string, SkillOutput, error) {
+	sk := s.registry.Get(name)
+	if sk == nil {
+		if qualified, ok := s.skillForTool(name); ok {
+			sk = s.registry.Get(qualified)
+		}
+	}
+	if sk == nil {
+		return "", SkillOutput{}, fmt.Errorf("%w: %q", ErrUnknownSkill, name)
+	}
+	return s.respond(sk.Localize(s.requestLocale(nil, input)), input)
 }
 
 // formatSkillResponse formats a skill as a text response.
@@ -119,6 +273,14 @@ func (s *Server) Run(ctx context.Context) error {
 	return s.mcp.Run(ctx, &mcp.StdioTransport{})
 }
 
+// skillForTool returns the qualified name of the skill registered under toolName.
+func (s *Server) skillForTool(toolName string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name, ok := s.tools[toolName]
+	return name, ok
+}
+
 // RunWithTransport starts the MCP server with a custom transport.
 // This is primarily useful for testing.
 func (s *Server) RunWithTransport(ctx context.Context, transport mcp.Transport) error {
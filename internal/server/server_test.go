@@ -151,6 +151,10 @@ Say hello politely.
 		t.Errorf("expected tool name 'greet', got %q", tools.Tools[0].Name)
 	}
 
+	if tokens, ok := tools.Tools[0].Meta["tokens"].(float64); !ok || tokens <= 0 {
+		t.Errorf("expected positive tokens in tool _meta, got %v", tools.Tools[0].Meta["tokens"])
+	}
+
 	// Call the tool
 	result, err := session.CallTool(ctx, &mcp.CallToolParams{
 		Name: "greet",
@@ -257,3 +261,160 @@ func TestIntegrationMultipleSkills(t *testing.T) {
 
 	cancel()
 }
+
+func TestIntegrationSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "guide")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatalf("failed to create skill dir: %v", err)
+	}
+
+	content := `---
+name: guide
+description: A sectioned guide
+---
+
+# Guide
+
+Read the overview first.
+
+## Setup
+
+Install the tools.
+
+## Troubleshooting
+
+Restart it.
+`
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	srv := New(reg, logger, WithSectionThreshold(0))
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		srv.RunWithTransport(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	defer session.Close()
+
+	callText := func(args map[string]any) (string, bool) {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "guide", Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool(%v) error: %v", args, err)
+		}
+		return result.Content[0].(*mcp.TextContent).Text, result.IsError
+	}
+
+	overview, _ := callText(nil)
+	if !strings.Contains(overview, "Read the overview first.") {
+		t.Error("overview response missing overview text")
+	}
+	if !strings.Contains(overview, "- Setup (`setup`)") {
+		t.Error("overview response missing table of contents")
+	}
+	if strings.Contains(overview, "Install the tools.") {
+		t.Error("overview response should not include section content")
+	}
+
+	section, isError := callText(map[string]any{"section": "setup"})
+	if isError {
+		t.Fatalf("section call returned error: %s", section)
+	}
+	if !strings.Contains(section, "Install the tools.") || strings.Contains(section, "Restart it.") {
+		t.Errorf("section response = %q, want only the Setup section", section)
+	}
+
+	missing, isError := callText(map[string]any{"section": "nope"})
+	if !isError {
+		t.Error("unknown section should return a tool error")
+	}
+	if !strings.Contains(missing, "troubleshooting") {
+		t.Errorf("unknown section error should list available sections, got %q", missing)
+	}
+}
+
+func TestIntegrationLocale(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestSkill(t, tmpDir, "greet", "Say hello.")
+	variant := "---\nname: greet\ndescription: ユーザーに挨拶する\n---\n\nこんにちはと言う。\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "greet", "SKILL.ja.md"), []byte(variant), 0644); err != nil {
+		t.Fatalf("failed to write variant: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		serverOpts []Option
+		clientMeta mcp.Meta
+		args       map[string]any
+		want       string
+	}{
+		{name: "default", want: "Say hello."},
+		{name: "server locale", serverOpts: []Option{WithLocale("ja")}, want: "こんにちはと言う。"},
+		{name: "client locale", clientMeta: mcp.Meta{"locale": "ja-JP"}, want: "こんにちはと言う。"},
+		{name: "argument", args: map[string]any{"locale": "ja"}, want: "こんにちはと言う。"},
+		{name: "argument overrides server", serverOpts: []Option{WithLocale("ja")}, args: map[string]any{"locale": "en"}, want: "Say hello."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := New(reg, logger, tt.serverOpts...)
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			go func() {
+				srv.RunWithTransport(ctx, serverTransport)
+			}()
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+			if tt.clientMeta != nil {
+				client.AddSendingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+					return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+						if params, ok := req.GetParams().(*mcp.InitializeParams); ok {
+							params.Meta = tt.clientMeta
+						}
+						return next(ctx, method, req)
+					}
+				})
+			}
+			session, err := client.Connect(ctx, clientTransport, nil)
+			if err != nil {
+				t.Fatalf("Connect() error: %v", err)
+			}
+			defer session.Close()
+
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "greet", Arguments: tt.args})
+			if err != nil {
+				t.Fatalf("CallTool() error: %v", err)
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("response = %q, want it to contain %q", text, tt.want)
+			}
+		})
+	}
+}
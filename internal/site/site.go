@@ -0,0 +1,315 @@
+// Package site renders a skills registry as a static HTML catalog for
+// people who want to read what skills exist without an agent.
+package site
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+)
+
+// DefaultTitle is the site title used when Options.Title is empty.
+const DefaultTitle = "Skills"
+
+//go:embed templates/*.html static/*
+var assets embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"size": formatSize,
+}).ParseFS(assets, "templates/*.html"))
+
+// markdown renders skill instructions. Raw HTML in them is omitted rather
+// than passed through.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// Options configures the generated site.
+type Options struct {
+	// Title is shown on every page. It defaults to DefaultTitle.
+	Title string
+
+	// Locale selects the localized SKILL.<lang>.md variants to render
+	// where available.
+	Locale string
+}
+
+// File is a generated file.
+type File struct {
+	// Path is the file's slash-separated path relative to the output
+	// directory.
+	Path string
+
+	// Data is the file's content.
+	Data []byte
+}
+
+// Page describes a skill's page.
+type Page struct {
+	Name        string
+	Namespace   string
+	Tool        string
+	Description string
+	Version     string
+	Owner       string
+	Tags        []string
+	Extends     []string
+	Locales     []string
+	Tokens      int
+
+	// Source is the skill's markdown file relative to the skills root.
+	Source string
+
+	// Modified is when the skill's file, or any file it includes or
+	// inherits from, was last changed, in UTC.
+	Modified time.Time
+
+	// Files lists the other files bundled in the skill's directory.
+	Files []Bundled
+
+	// Path is the page's path relative to the output directory.
+	Path string
+
+	// HTML is the skill's rendered instructions.
+	HTML template.HTML
+}
+
+// Bundled is a file bundled with a skill.
+type Bundled struct {
+	// Path is the file's slash-separated path relative to the skill's
+	// directory.
+	Path string
+	Size int64
+}
+
+// Build renders reg's skills as an index page, a page per skill and the
+// stylesheet and script they share.
+func Build(reg *registry.Registry, opts Options) ([]File, error) {
+	if opts.Title == "" {
+		opts.Title = DefaultTitle
+	}
+
+	paths, err := reg.Files(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("list skill files: %w", err)
+	}
+	skills := newSkillTree(paths)
+
+	var (
+		pages []Page
+		tags  = make(map[string]bool)
+	)
+	for _, s := range reg.List() {
+		p, err := page(reg.Root(), s, s.Localize(opts.Locale), skills)
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", s.QualifiedName(), err)
+		}
+		for _, tag := range p.Tags {
+			tags[tag] = true
+		}
+		pages = append(pages, p)
+	}
+
+	allTags := make([]string, 0, len(tags))
+	for tag := range tags {
+		allTags = append(allTags, tag)
+	}
+	sort.Strings(allTags)
+
+	var files []File
+	index, err := execute("index.html", map[string]any{
+		"Title": opts.Title,
+		"Pages": pages,
+		"Tags":  allTags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, File{Path: "index.html", Data: index})
+
+	for _, p := range pages {
+		data, err := execute("skill.html", map[string]any{
+			"Title": opts.Title,
+			"Page":  p,
+			"Base":  "../",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("skill %q: %w", p.Name, err)
+		}
+		files = append(files, File{Path: p.Path, Data: data})
+	}
+
+	static, err := fs.Glob(assets, "static/*")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range static {
+		data, err := assets.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: name, Data: data})
+	}
+	return files, nil
+}
+
+// Write writes files under dir, creating directories as needed.
+func Write(dir string, files []File) error {
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.Data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// page describes s, rendering the localized variant l. skills locates the
+// other skills, whose files are not listed as bundled with s.
+func page(root string, s, l *skill.Skill, skills skillTree) (Page, error) {
+	var html bytes.Buffer
+	if err := markdown.Convert([]byte(l.Instructions), &html); err != nil {
+		return Page{}, fmt.Errorf("render instructions: %w", err)
+	}
+
+	p := Page{
+		Name:        s.QualifiedName(),
+		Namespace:   s.Namespace,
+		Tool:        s.Tool,
+		Description: l.Description,
+		Version:     s.Version,
+		Owner:       s.Owner,
+		Tags:        s.Tags,
+		Locales:     s.Locales(),
+		Tokens:      l.Tokens,
+		Source:      registry.RelPath(root, l.File),
+		Modified:    lastModified(append([]string{l.File}, l.Includes...)).UTC(),
+		Path:        "skills/" + s.Tool + ".html",
+		HTML:        template.HTML(html.String()),
+	}
+	if len(s.Lineage) > 1 {
+		p.Extends = s.Lineage[1:]
+	}
+	if filepath.Base(s.File) == "SKILL.md" {
+		files, err := bundled(s.Path, skills)
+		if err != nil {
+			return Page{}, fmt.Errorf("list bundled files: %w", err)
+		}
+		p.Files = files
+	}
+	return p, nil
+}
+
+// skillTree records where the skill files under a root are.
+type skillTree struct {
+	files map[string]bool // skill file paths
+	dirs  map[string]bool // directories with a skill file at any depth below
+}
+
+// newSkillTree returns the skill tree of the skill files at paths.
+func newSkillTree(paths []string) skillTree {
+	t := skillTree{files: make(map[string]bool), dirs: make(map[string]bool)}
+	for _, path := range paths {
+		t.files[path] = true
+		for dir := filepath.Dir(path); !t.dirs[dir]; dir = filepath.Dir(dir) {
+			t.dirs[dir] = true
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+	return t
+}
+
+// bundled lists the files in a skill directory other than its SKILL.md and
+// localized variants, skipping hidden files, other skill files and any
+// subdirectory with a skill file at any depth below it.
+func bundled(dir string, skills skillTree) ([]Bundled, error) {
+	var files []Bundled
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if skills.dirs[path] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if skills.files[path] {
+			return nil
+		}
+		rel := registry.RelPath(dir, path)
+		if !strings.Contains(rel, "/") && strings.HasPrefix(rel, "SKILL.") && strings.HasSuffix(rel, ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, Bundled{Path: rel, Size: info.Size()})
+		return nil
+	})
+	return files, err
+}
+
+// lastModified returns the latest modification time of paths, ignoring
+// those that cannot be read.
+func lastModified(paths []string) time.Time {
+	var latest time.Time
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// execute runs the named template with data.
+func execute(name string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatSize formats a file size in bytes for people.
+func formatSize(n int64) string {
+	switch {
+	case n < 1<<10:
+		return fmt.Sprintf("%d B", n)
+	case n < 1<<20:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	}
+}
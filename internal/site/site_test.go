@@ -0,0 +1,151 @@
+package site
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	review := filepath.Join(root, "review", "SKILL.md")
+	writeFile(t, review, `---
+name: review
+description: Review <b>code</b>
+version: 1.2.0
+owner: platform-team
+tags: [quality, go]
+---
+
+# Review
+
+Check the **tests**.
+
+<script>alert(1)</script>
+`)
+	writeFile(t, filepath.Join(root, "review", "references", "checklist.md"), "- tests\n")
+	writeFile(t, filepath.Join(root, "review", "SKILL.ja.md"), "---\nname: review\ndescription: レビュー\n---\n\nテストを確認する。\n")
+	writeFile(t, filepath.Join(root, "review", ".notes"), "hidden\n")
+	writeFile(t, filepath.Join(root, "review", "nested", "SKILL.md"), "---\nname: nested\ndescription: Nested\n---\n\nNested.\n")
+	writeFile(t, filepath.Join(root, "review", "team", "notes.md"), "Team notes.\n")
+	writeFile(t, filepath.Join(root, "review", "team", "go", "SKILL.md"), "---\nname: go\ndescription: Go\n---\n\nGo.\n")
+	writeFile(t, filepath.Join(root, "review", "tools", "lint.skill.md"), "---\nname: lint\ndescription: Lint\n---\n\nLint.\n")
+	writeFile(t, filepath.Join(root, "review", "scripts", "run.sh"), "#!/bin/sh\n")
+	writeFile(t, filepath.Join(root, "deploy", "SKILL.md"), "---\nname: deploy\ndescription: Deploy\ntags: [ops]\n---\n\nShip it.\n")
+
+	modified := time.Date(2025, 3, 14, 9, 26, 0, 0, time.UTC)
+	if err := os.Chtimes(review, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	reg := registry.NewRegistry(root, testLogger())
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Build(reg, Options{Title: "Team Skills"})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	byPath := make(map[string]string)
+	var paths []string
+	for _, f := range files {
+		byPath[f.Path] = string(f.Data)
+		paths = append(paths, f.Path)
+	}
+	want := []string{"index.html", "skills/deploy.html", "skills/go.html", "skills/lint.html", "skills/nested.html", "skills/review.html", "static/site.css", "static/site.js"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("paths = %q, want %q", paths, want)
+	}
+
+	index := byPath["index.html"]
+	for _, s := range []string{
+		"<title>Team Skills</title>",
+		`href="skills/review.html"`,
+		`data-tag="quality"`,
+		`data-tag="ops"`,
+		"v1.2.0 · platform-team · updated 2025-03-14",
+		"Review &lt;b&gt;code&lt;/b&gt;",
+	} {
+		if !strings.Contains(index, s) {
+			t.Errorf("index.html missing %q", s)
+		}
+	}
+
+	page := byPath["skills/review.html"]
+	for _, s := range []string{
+		`<h1 id="review">Review</h1>`,
+		"<strong>tests</strong>",
+		"<dt>Owner</dt><dd>platform-team</dd>",
+		"<dt>Version</dt><dd>1.2.0</dd>",
+		`datetime="2025-03-14T09:26:00Z"`,
+		"<dt>Languages</dt><dd>ja</dd>",
+		"<code>references/checklist.md</code>",
+		"<code>scripts/run.sh</code>",
+		`href="../static/site.css"`,
+	} {
+		if !strings.Contains(page, s) {
+			t.Errorf("skills/review.html missing %q", s)
+		}
+	}
+	for _, s := range []string{"<script>alert(1)</script>", ".notes", "nested/SKILL.md", "SKILL.ja.md", "team/", "lint.skill.md"} {
+		if strings.Contains(page, s) {
+			t.Errorf("skills/review.html should not contain %q", s)
+		}
+	}
+}
+
+func TestBuildLocale(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "review", "SKILL.md"), "---\nname: review\ndescription: Review\n---\n\nCheck the tests.\n")
+	writeFile(t, filepath.Join(root, "review", "SKILL.ja.md"), "---\nname: review\ndescription: レビュー\n---\n\nテストを確認する。\n")
+
+	reg := registry.NewRegistry(root, testLogger())
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Build(reg, Options{Locale: "ja"})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	for _, f := range files {
+		if f.Path == "skills/review.html" && !strings.Contains(string(f.Data), "テストを確認する。") {
+			t.Errorf("review page not localized:\n%s", f.Data)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	files := []File{
+		{Path: "index.html", Data: []byte("index")},
+		{Path: "skills/a.html", Data: []byte("a")},
+	}
+	if err := Write(dir, files); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "skills", "a.html"))
+	if err != nil || string(data) != "a" {
+		t.Errorf("skills/a.html = %q, %v", data, err)
+	}
+}
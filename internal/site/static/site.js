@@ -0,0 +1,35 @@
+// Filters the skill index by the search text and the selected tags. A skill
+// is shown when it contains every word searched for and has every selected tag.
+(function () {
+  var search = document.getElementById("search");
+  var skills = Array.prototype.slice.call(document.querySelectorAll(".skill"));
+  var buttons = Array.prototype.slice.call(document.querySelectorAll(".tag-filter"));
+  var shown = document.getElementById("shown");
+  var empty = document.getElementById("empty");
+  var selected = {};
+
+  function update() {
+    var words = search.value.toLowerCase().split(/\s+/).filter(Boolean);
+    var count = 0;
+    skills.forEach(function (skill) {
+      var text = skill.dataset.search.toLowerCase();
+      var tags = skill.dataset.tags.split("\n");
+      var visible = words.every(function (w) { return text.indexOf(w) >= 0; }) &&
+        Object.keys(selected).every(function (t) { return tags.indexOf(t) >= 0; });
+      skill.hidden = !visible;
+      if (visible) count++;
+    });
+    shown.textContent = count;
+    if (empty) empty.hidden = count > 0;
+  }
+
+  search.addEventListener("input", update);
+  buttons.forEach(function (button) {
+    button.addEventListener("click", function () {
+      var tag = button.dataset.tag;
+      if (selected[tag]) delete selected[tag]; else selected[tag] = true;
+      button.setAttribute("aria-pressed", selected[tag] ? "true" : "false");
+      update();
+    });
+  });
+})();
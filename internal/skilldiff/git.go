@@ -0,0 +1,102 @@
+package skilldiff
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsafePath is returned when a git archive holds a path outside the
+// directory it is extracted to.
+var ErrUnsafePath = errors.New("unsafe path in archive")
+
+// ExtractTree writes the files of treeish, a commit or tree in the git
+// repository at repo, into dir. treeish takes any form git accepts, such as
+// "main", "v1.2.0" or "HEAD~3:skills" for a subdirectory.
+func ExtractTree(ctx context.Context, repo, treeish, dir string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", repo, "archive", "--format=tar", treeish)
+	cmd.Stderr = &stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("git archive: %w", err)
+	}
+
+	extractErr := extract(tar.NewReader(out), dir)
+	// Drain the archive so git is not blocked writing the rest of it.
+	io.Copy(io.Discard, out)
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git archive %s: %s", treeish, msg)
+		}
+		return fmt.Errorf("git archive %s: %w", treeish, err)
+	}
+	return extractErr
+}
+
+// extract writes the directories, regular files and symlinks of a tar
+// archive into dir. Every entry is written through an os.Root, so neither
+// its name nor a symlink earlier in the archive can place it outside dir.
+func extract(tr *tar.Reader, dir string) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		name := filepath.FromSlash(hdr.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("%w: %s", ErrUnsafePath, hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = root.MkdirAll(name, 0o755)
+		case tar.TypeReg:
+			err = writeEntry(root, name, hdr.FileInfo().Mode().Perm(), tr)
+		case tar.TypeSymlink:
+			// The registry's symlink policy decides whether links are
+			// followed, as it would for a checkout.
+			if err = root.MkdirAll(filepath.Dir(name), 0o755); err == nil {
+				err = root.Symlink(hdr.Linkname, name)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", hdr.Name, err)
+		}
+	}
+}
+
+// writeEntry writes the contents of a regular file entry to name in root.
+func writeEntry(root *os.Root, name string, perm os.FileMode, r io.Reader) error {
+	if err := root.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
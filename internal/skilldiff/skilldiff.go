@@ -0,0 +1,258 @@
+// Package skilldiff compares two scanned skill sets: which skills were
+// added, removed, renamed or modified, how their frontmatter and tool names
+// changed, and how their instructions differ.
+package skilldiff
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of Change.
+const (
+	Added    = "added"
+	Removed  = "removed"
+	Renamed  = "renamed"
+	Modified = "modified"
+)
+
+// Change is a difference in one skill between the old and new skill sets.
+type Change struct {
+	// Kind is Added, Removed, Renamed or Modified.
+	Kind string `json:"kind"`
+
+	// Name is the skill's qualified name, in the new set unless removed.
+	// OldName is its name in the old set, if renamed.
+	Name    string `json:"name"`
+	OldName string `json:"old_name,omitempty"`
+
+	// Tool is the skill's tool name, in the new set unless removed.
+	// OldTool is its tool name in the old set, if it changed.
+	Tool    string `json:"tool"`
+	OldTool string `json:"old_tool,omitempty"`
+
+	// File is the skill's markdown file relative to its root, in the new
+	// set unless removed. OldFile is its old file, if it moved.
+	File    string `json:"file"`
+	OldFile string `json:"old_file,omitempty"`
+
+	// Fields are the frontmatter fields that changed.
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	// Diff is a unified diff of the skill's resolved instructions, empty if
+	// they did not change.
+	Diff string `json:"diff,omitempty"`
+}
+
+// FieldChange is a changed frontmatter field. Old or New is nil when the
+// field is unset on that side.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Summary counts the changes between two skill sets.
+type Summary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Renamed   int `json:"renamed"`
+	Modified  int `json:"modified"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Result is the comparison of two skill sets.
+type Result struct {
+	Summary Summary  `json:"summary"`
+	Changes []Change `json:"changes"`
+}
+
+// Changed reports whether the skill sets differ.
+func (r Result) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Compare compares the registered skills of the old and new registries,
+// matching skills by qualified name. A removed and an added skill in the
+// same place relative to their roots are reported as a rename, as are a
+// removed and an added skill that alone share the same non-empty
+// instructions. Changes are ordered by kind, then name.
+func Compare(oldReg, newReg *registry.Registry) Result {
+	oldSkills := byName(oldReg)
+	newSkills := byName(newReg)
+
+	var (
+		res            Result
+		removed, added []*skill.Skill
+	)
+	for name, o := range oldSkills {
+		n, ok := newSkills[name]
+		if !ok {
+			removed = append(removed, o)
+			continue
+		}
+		if c, changed := compareSkill(oldReg.Root(), newReg.Root(), o, n); changed {
+			res.Changes = append(res.Changes, c)
+		} else {
+			res.Summary.Unchanged++
+		}
+	}
+	for name, n := range newSkills {
+		if _, ok := oldSkills[name]; !ok {
+			added = append(added, n)
+		}
+	}
+	sortByName(removed)
+	sortByName(added)
+
+	samePath := func(o, n *skill.Skill) bool {
+		return registry.RelPath(oldReg.Root(), o.File) == registry.RelPath(newReg.Root(), n.File)
+	}
+	sameInstructions := func(o, n *skill.Skill) bool {
+		return o.Instructions != "" && o.Instructions == n.Instructions &&
+			count(removed, o.Instructions) == 1 && count(added, n.Instructions) == 1
+	}
+	for _, match := range []func(o, n *skill.Skill) bool{samePath, sameInstructions} {
+		for i := 0; i < len(removed); i++ {
+			for j, n := range added {
+				if !match(removed[i], n) {
+					continue
+				}
+				c, _ := compareSkill(oldReg.Root(), newReg.Root(), removed[i], n)
+				res.Changes = append(res.Changes, c)
+				removed = append(removed[:i], removed[i+1:]...)
+				added = append(added[:j], added[j+1:]...)
+				i--
+				break
+			}
+		}
+	}
+
+	for _, s := range removed {
+		res.Changes = append(res.Changes, Change{Kind: Removed, Name: s.QualifiedName(), Tool: s.Tool, File: registry.RelPath(oldReg.Root(), s.File)})
+	}
+	for _, s := range added {
+		res.Changes = append(res.Changes, Change{Kind: Added, Name: s.QualifiedName(), Tool: s.Tool, File: registry.RelPath(newReg.Root(), s.File)})
+	}
+
+	order := map[string]int{Added: 0, Removed: 1, Renamed: 2, Modified: 3}
+	sort.SliceStable(res.Changes, func(i, j int) bool {
+		a, b := res.Changes[i], res.Changes[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	for _, c := range res.Changes {
+		switch c.Kind {
+		case Added:
+			res.Summary.Added++
+		case Removed:
+			res.Summary.Removed++
+		case Renamed:
+			res.Summary.Renamed++
+		case Modified:
+			res.Summary.Modified++
+		}
+	}
+	return res
+}
+
+// compareSkill compares the old and new versions of a skill, reporting a
+// rename if their names differ, and whether anything changed.
+func compareSkill(oldRoot, newRoot string, o, n *skill.Skill) (Change, bool) {
+	c := Change{
+		Kind: Modified,
+		Name: n.QualifiedName(),
+		Tool: n.Tool,
+		File: registry.RelPath(newRoot, n.File),
+	}
+	if o.QualifiedName() != c.Name {
+		c.Kind = Renamed
+		c.OldName = o.QualifiedName()
+	}
+	if o.Tool != n.Tool {
+		c.OldTool = o.Tool
+	}
+	if oldFile := registry.RelPath(oldRoot, o.File); oldFile != c.File {
+		c.OldFile = oldFile
+	}
+	c.Fields = compareFrontmatter(o, n)
+	c.Diff = Unified("a/"+registry.RelPath(oldRoot, o.File), "b/"+c.File, o.Instructions, n.Instructions, DefaultContext)
+
+	changed := c.Kind == Renamed || c.OldTool != "" || c.OldFile != "" || len(c.Fields) > 0 || c.Diff != ""
+	return c, changed
+}
+
+// compareFrontmatter returns the frontmatter fields that differ between o
+// and n, in field name order. Fields are compared as they are after
+// resolution, so an inherited description counts as the skill's own.
+func compareFrontmatter(o, n *skill.Skill) []FieldChange {
+	oldFields, newFields := frontmatter(o), frontmatter(n)
+
+	names := make(map[string]bool)
+	for name := range oldFields {
+		names[name] = true
+	}
+	for name := range newFields {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, name := range sorted {
+		if !reflect.DeepEqual(oldFields[name], newFields[name]) {
+			changes = append(changes, FieldChange{Field: name, Old: oldFields[name], New: newFields[name]})
+		}
+	}
+	return changes
+}
+
+// frontmatter returns s's frontmatter fields as a generic map, keyed by
+// their YAML names and holding only the fields that are set.
+func frontmatter(s *skill.Skill) map[string]any {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// count returns how many of skills have the given instructions.
+func count(skills []*skill.Skill, instructions string) int {
+	n := 0
+	for _, s := range skills {
+		if s.Instructions == instructions {
+			n++
+		}
+	}
+	return n
+}
+
+// byName returns reg's skills keyed by qualified name.
+func byName(reg *registry.Registry) map[string]*skill.Skill {
+	skills := make(map[string]*skill.Skill)
+	for _, s := range reg.List() {
+		skills[s.QualifiedName()] = s
+	}
+	return skills
+}
+
+// sortByName sorts skills by qualified name.
+func sortByName(skills []*skill.Skill) {
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].QualifiedName() < skills[j].QualifiedName()
+	})
+}
@@ -0,0 +1,394 @@
+package skilldiff
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func scan(t *testing.T, root string) *registry.Registry {
+	t.Helper()
+	reg := registry.NewRegistry(root, testLogger())
+	if err := reg.Scan(); err != nil {
+		t.Fatal(err)
+	}
+	return reg
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		context  int
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name:    "change in the middle",
+			old:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:     "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			context: 2,
+			want:    "--- a\n+++ b\n@@ -3,5 +3,5 @@\n 3\n 4\n-5\n+five\n 6\n 7\n",
+		},
+		{
+			name:    "separate hunks",
+			old:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:     "one\n2\n3\n4\n5\n6\n7\n8\nnine\n",
+			context: 1,
+			want:    "--- a\n+++ b\n@@ -1,2 +1,2 @@\n-1\n+one\n 2\n@@ -8,2 +8,2 @@\n 8\n-9\n+nine\n",
+		},
+		{
+			name:    "overlapping context merges hunks",
+			old:     "1\n2\n3\n4\n5\n",
+			new:     "one\n2\n3\n4\nfive\n",
+			context: 2,
+			want:    "--- a\n+++ b\n@@ -1,5 +1,5 @@\n-1\n+one\n 2\n 3\n 4\n-5\n+five\n",
+		},
+		{
+			name:    "from empty",
+			old:     "",
+			new:     "a\nb\n",
+			context: 3,
+			want:    "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:    "insertion",
+			old:     "a\nc\n",
+			new:     "a\nb\nc\n",
+			context: 0,
+			want:    "--- a\n+++ b\n@@ -1,0 +2 @@\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a", "b", tt.old, tt.new, tt.context)
+			if got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedLargeRewrite(t *testing.T) {
+	var oldText, newText strings.Builder
+	for i := range 4000 {
+		fmt.Fprintf(&oldText, "old line %d\n", i)
+		fmt.Fprintf(&newText, "new line %d\n", i)
+	}
+
+	got := Unified("a", "b", oldText.String(), newText.String(), DefaultContext)
+	header := "--- a\n+++ b\n@@ -1,4000 +1,4000 @@\n-old line 0\n"
+	if !strings.HasPrefix(got, header) || strings.Count(got, "@@") != 2 {
+		t.Errorf("Unified() of a full rewrite is not one hunk replacing the file:\n%.200s", got)
+	}
+}
+
+func TestDiffLinesWithinLimit(t *testing.T) {
+	var a, b []string
+	for i := range 3000 {
+		a = append(a, fmt.Sprint(i))
+		if i%10 == 0 {
+			b = append(b, fmt.Sprintf("changed %d", i))
+		} else {
+			b = append(b, fmt.Sprint(i))
+		}
+	}
+
+	var gotA, gotB []string
+	changes := 0
+	for _, e := range diffLines(a, b) {
+		if e.kind != '+' {
+			gotA = append(gotA, e.line)
+		}
+		if e.kind != '-' {
+			gotB = append(gotB, e.line)
+		}
+		if e.kind != ' ' {
+			changes++
+		}
+	}
+	if !slices.Equal(gotA, a) || !slices.Equal(gotB, b) {
+		t.Fatal("diffLines() edits do not turn a into b")
+	}
+	if changes != 600 {
+		t.Errorf("diffLines() made %d changes, want the shortest script of 600", changes)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	oldRoot, newRoot := t.TempDir(), t.TempDir()
+
+	writeFile(t, filepath.Join(oldRoot, "keep", "SKILL.md"), "---\nname: keep\ndescription: Keep\n---\n\nKeep.\n")
+	writeFile(t, filepath.Join(newRoot, "keep", "SKILL.md"), "---\nname: keep\ndescription: Keep\n---\n\nKeep.\n")
+
+	writeFile(t, filepath.Join(oldRoot, "gone", "SKILL.md"), "---\nname: gone\ndescription: Gone\n---\n\nGone.\n")
+	writeFile(t, filepath.Join(newRoot, "fresh", "SKILL.md"), "---\nname: fresh\ndescription: Fresh\n---\n\nFresh.\n")
+
+	// Renamed and moved, with the same instructions.
+	writeFile(t, filepath.Join(oldRoot, "lint", "SKILL.md"), "---\nname: lint\ndescription: Lint\n---\n\nRun the linter.\n")
+	writeFile(t, filepath.Join(newRoot, "check", "SKILL.md"), "---\nname: check\ndescription: Lint\n---\n\nRun the linter.\n")
+
+	// Renamed in place, with new instructions.
+	writeFile(t, filepath.Join(oldRoot, "deploy", "SKILL.md"), "---\nname: deploy\ndescription: Deploy\n---\n\nShip it.\n")
+	writeFile(t, filepath.Join(newRoot, "deploy", "SKILL.md"), "---\nname: release\ndescription: Deploy\n---\n\nShip it carefully.\n")
+
+	writeFile(t, filepath.Join(oldRoot, "review", "SKILL.md"), "---\nname: review\ndescription: Review\ntags: [go]\n---\n\nCheck tests.\n")
+	writeFile(t, filepath.Join(newRoot, "review", "SKILL.md"), "---\nname: review\ndescription: Review\ntool_name: code_review\ntags: [go, quality]\nversion: 2.0.0\n---\n\nCheck tests.\nCheck docs.\n")
+
+	res := Compare(scan(t, oldRoot), scan(t, newRoot))
+
+	want := Summary{Added: 1, Removed: 1, Renamed: 2, Modified: 1, Unchanged: 1}
+	if res.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", res.Summary, want)
+	}
+	if !res.Changed() {
+		t.Error("Changed() = false, want true")
+	}
+
+	var kinds []string
+	for _, c := range res.Changes {
+		kinds = append(kinds, c.Kind+" "+c.Name)
+	}
+	wantKinds := []string{"added fresh", "removed gone", "renamed check", "renamed release", "modified review"}
+	if strings.Join(kinds, ",") != strings.Join(wantKinds, ",") {
+		t.Fatalf("changes = %v, want %v", kinds, wantKinds)
+	}
+
+	check := res.Changes[2]
+	if check.OldName != "lint" || check.OldTool != "lint" || check.Tool != "check" {
+		t.Errorf("check = %+v, want rename from lint", check)
+	}
+	if check.OldFile != "lint/SKILL.md" || check.File != "check/SKILL.md" {
+		t.Errorf("check files = %q -> %q", check.OldFile, check.File)
+	}
+	if check.Diff != "" {
+		t.Errorf("check.Diff = %q, want none", check.Diff)
+	}
+
+	release := res.Changes[3]
+	if release.OldName != "deploy" || release.OldFile != "" {
+		t.Errorf("release = %+v, want in-place rename from deploy", release)
+	}
+	if !strings.Contains(release.Diff, "-Ship it.\n+Ship it carefully.\n") {
+		t.Errorf("release.Diff = %q", release.Diff)
+	}
+
+	review := res.Changes[4]
+	if review.OldTool != "review" || review.Tool != "code_review" {
+		t.Errorf("review tools = %q -> %q", review.OldTool, review.Tool)
+	}
+	fields := make(map[string]FieldChange)
+	for _, f := range review.Fields {
+		fields[f.Field] = f
+	}
+	if len(fields) != 3 {
+		t.Errorf("review.Fields = %+v, want tags, tool_name and version", review.Fields)
+	}
+	if f := fields["version"]; f.Old != nil || f.New != "2.0.0" {
+		t.Errorf("version change = %+v", f)
+	}
+	if _, ok := fields["tags"]; !ok {
+		t.Error("tags change missing")
+	}
+	wantDiff := "--- a/review/SKILL.md\n+++ b/review/SKILL.md\n@@ -1 +1,2 @@\n Check tests.\n+Check docs.\n"
+	if review.Diff != wantDiff {
+		t.Errorf("review.Diff =\n%s\nwant:\n%s", review.Diff, wantDiff)
+	}
+}
+
+func TestCompareAmbiguousRenames(t *testing.T) {
+	oldRoot, newRoot := t.TempDir(), t.TempDir()
+
+	// Stubs sharing their instructions, and skills without instructions,
+	// are not paired up as renames.
+	writeFile(t, filepath.Join(oldRoot, "a", "SKILL.md"), "---\nname: a\ndescription: A\n---\n\nTODO\n")
+	writeFile(t, filepath.Join(oldRoot, "b", "SKILL.md"), "---\nname: b\ndescription: B\n---\n\nTODO\n")
+	writeFile(t, filepath.Join(newRoot, "c", "SKILL.md"), "---\nname: c\ndescription: C\n---\n\nTODO\n")
+	writeFile(t, filepath.Join(newRoot, "d", "SKILL.md"), "---\nname: d\ndescription: D\n---\n\nTODO\n")
+	writeFile(t, filepath.Join(oldRoot, "e", "SKILL.md"), "---\nname: e\ndescription: E\n---\n")
+	writeFile(t, filepath.Join(newRoot, "f", "SKILL.md"), "---\nname: f\ndescription: F\n---\n")
+
+	res := Compare(scan(t, oldRoot), scan(t, newRoot))
+	want := Summary{Added: 3, Removed: 3}
+	if res.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", res.Summary, want)
+	}
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "keep", "SKILL.md"), "---\nname: keep\ndescription: Keep\n---\n\nKeep.\n")
+
+	res := Compare(scan(t, root), scan(t, root))
+	if res.Changed() {
+		t.Errorf("Changes = %+v, want none", res.Changes)
+	}
+	if res.Summary.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", res.Summary.Unchanged)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, res); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"changes": []`) {
+		t.Errorf("WriteJSON() = %s, want empty changes list", buf.String())
+	}
+}
+
+func TestWrite(t *testing.T) {
+	res := Result{
+		Summary: Summary{Modified: 1},
+		Changes: []Change{{
+			Kind:    Modified,
+			Name:    "review",
+			Tool:    "code_review",
+			OldTool: "review",
+			File:    "review/SKILL.md",
+			Fields:  []FieldChange{{Field: "version", New: "2.0.0"}},
+			Diff:    "--- a/review/SKILL.md\n+++ b/review/SKILL.md\n@@ -1 +1 @@\n-old\n+new\n",
+		}},
+	}
+
+	var text bytes.Buffer
+	if err := WriteText(&text, res); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"modified  review\n",
+		"  tool: review -> code_review\n",
+		"  version: (unset) -> \"2.0.0\"\n",
+		"  -old\n  +new\n",
+		"0 added, 0 removed, 0 renamed, 1 modified, 0 unchanged\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("WriteText() missing %q in:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := WriteJSON(&out, res); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Result
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() is not valid JSON: %v", err)
+	}
+	if len(decoded.Changes) != 1 || decoded.Changes[0].OldTool != "review" || decoded.Changes[0].Diff != res.Changes[0].Diff {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
+
+func TestExtractTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com", "GIT_CONFIG_GLOBAL=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	writeFile(t, filepath.Join(repo, "skills", "review", "SKILL.md"), "---\nname: review\ndescription: Review\n---\n\nv1\n")
+	git("add", "-A")
+	git("commit", "-q", "-m", "v1")
+	writeFile(t, filepath.Join(repo, "skills", "review", "SKILL.md"), "---\nname: review\ndescription: Review\n---\n\nv2\n")
+	git("commit", "-q", "-am", "v2")
+
+	dir := t.TempDir()
+	if err := ExtractTree(context.Background(), repo, "HEAD~1:skills", dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "review", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "v1\n") {
+		t.Errorf("extracted SKILL.md = %q, want v1", data)
+	}
+
+	if err := ExtractTree(context.Background(), repo, "no-such-ref", t.TempDir()); err == nil {
+		t.Error("ExtractTree() with unknown ref succeeded, want error")
+	}
+}
+
+func TestExtractSymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	entries := []*tar.Header{
+		{Name: "a", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "a/x", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+	}
+	for _, hdr := range entries {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte("owned")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := extract(tar.NewReader(&buf), t.TempDir()); err == nil {
+		t.Error("extract() through a symlink out of the directory succeeded, want error")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "x")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the directory: %v", err)
+	}
+
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "../x", Typeflag: tar.TypeReg, Mode: 0o644}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := extract(tar.NewReader(&buf), t.TempDir()); !errors.Is(err, ErrUnsafePath) {
+		t.Errorf("extract() error = %v, want %v", err, ErrUnsafePath)
+	}
+}
@@ -0,0 +1,72 @@
+package skilldiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText writes res for people: a line per changed skill with its
+// frontmatter and tool name changes, the instruction diffs, and a summary.
+func WriteText(w io.Writer, res Result) error {
+	var sb strings.Builder
+	for _, c := range res.Changes {
+		switch c.Kind {
+		case Renamed:
+			fmt.Fprintf(&sb, "%-9s %s -> %s\n", c.Kind, c.OldName, c.Name)
+		default:
+			fmt.Fprintf(&sb, "%-9s %s\n", c.Kind, c.Name)
+		}
+		if c.Kind == Added || c.Kind == Removed {
+			fmt.Fprintf(&sb, "  tool: %s\n  file: %s\n", c.Tool, c.File)
+			continue
+		}
+		if c.OldTool != "" {
+			fmt.Fprintf(&sb, "  tool: %s -> %s\n", c.OldTool, c.Tool)
+		}
+		if c.OldFile != "" {
+			fmt.Fprintf(&sb, "  file: %s -> %s\n", c.OldFile, c.File)
+		}
+		for _, f := range c.Fields {
+			fmt.Fprintf(&sb, "  %s: %s -> %s\n", f.Field, formatValue(f.Old), formatValue(f.New))
+		}
+		if c.Diff != "" {
+			for _, line := range strings.Split(strings.TrimSuffix(c.Diff, "\n"), "\n") {
+				fmt.Fprintf(&sb, "  %s\n", line)
+			}
+		}
+	}
+	if res.Changed() {
+		sb.WriteString("\n")
+	}
+	s := res.Summary
+	fmt.Fprintf(&sb, "%d added, %d removed, %d renamed, %d modified, %d unchanged\n",
+		s.Added, s.Removed, s.Renamed, s.Modified, s.Unchanged)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON writes res as indented JSON.
+func WriteJSON(w io.Writer, res Result) error {
+	if res.Changes == nil {
+		res.Changes = []Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// formatValue formats a frontmatter value for display: as JSON, or
+// "(unset)" for nil.
+func formatValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,187 @@
+package skilldiff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change
+// in a unified diff.
+const DefaultContext = 3
+
+// maxEditDistance bounds the inserted plus deleted lines diffLines searches
+// for. The trace it keeps grows with the square of the edit distance, so
+// past this a text is shown as replaced whole instead.
+const maxEditDistance = 1000
+
+// edit is one line of an edit script: kept (' '), deleted ('-') or
+// inserted ('+'). a and b are the line's position in the old and new text,
+// counted in lines before it.
+type edit struct {
+	kind byte
+	a, b int
+	line string
+}
+
+// Unified returns a unified diff turning oldText into newText, labelled
+// oldName and newName, with context unchanged lines around each change. It
+// returns "" if the texts are equal.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+	edits := diffLines(splitLines(oldText), splitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(edits, context) {
+		writeHunk(&sb, edits[h[0]:h[1]])
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines, with none for empty text.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines returns a shortest edit script turning a into b, using Myers'
+// algorithm, or one deleting all of a and inserting all of b if that
+// would take more than maxEditDistance edits.
+func diffLines(a, b []string) []edit {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+
+	// trace[d] holds v[-d-1:d+2] from before step d, the diagonals that
+	// step reads.
+	var trace [][]int
+search:
+	for d := 0; d <= limit; d++ {
+		if d > maxEditDistance {
+			return replaceLines(a, b)
+		}
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk back from the end through the furthest reaching paths.
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v, base := trace[d], d+1
+		k := x - y
+		var prevK int
+		if k == -d || k != d && v[base+k-1] < v[base+k+1] {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[base+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: ' ', a: x, b: y, line: a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{kind: '+', a: x, b: y, line: b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{kind: '-', a: x, b: y, line: a[x]})
+		}
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// replaceLines returns an edit script deleting every line of a and then
+// inserting every line of b.
+func replaceLines(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	for i, line := range a {
+		edits = append(edits, edit{kind: '-', a: i, line: line})
+	}
+	for j, line := range b {
+		edits = append(edits, edit{kind: '+', a: len(a), b: j, line: line})
+	}
+	return edits
+}
+
+// hunks returns the [start, end) ranges of edits to print as hunks: each
+// change with up to context kept lines around it, merging changes whose
+// context would overlap.
+func hunks(edits []edit, context int) [][2]int {
+	var ranges [][2]int
+	for i, e := range edits {
+		if e.kind == ' ' {
+			continue
+		}
+		start, end := max(0, i-context), min(len(edits), i+context+1)
+		if n := len(ranges); n > 0 && start <= ranges[n-1][1] {
+			ranges[n-1][1] = end
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// writeHunk writes a hunk header and its lines.
+func writeHunk(sb *strings.Builder, edits []edit) {
+	var oldLen, newLen int
+	for _, e := range edits {
+		if e.kind != '+' {
+			oldLen++
+		}
+		if e.kind != '-' {
+			newLen++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(edits[0].a, oldLen), hunkRange(edits[0].b, newLen))
+	for _, e := range edits {
+		sb.WriteByte(e.kind)
+		sb.WriteString(e.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange formats the start and length of one side of a hunk. Lines are
+// numbered from 1; an empty range names the line before it.
+func hunkRange(start, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
@@ -0,0 +1,176 @@
+// Package skillfmt rewrites SKILL.md files in a canonical form, so that
+// diffs between versions of a skill show only what changed in it.
+package skillfmt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrNotMapping is returned for frontmatter that is not a YAML mapping.
+	ErrNotMapping = errors.New("frontmatter is not a YAML mapping")
+
+	// ErrUnstable is returned when the canonical frontmatter would not
+	// decode to the same values as the original, which Format refuses to
+	// write.
+	ErrUnstable = errors.New("canonical frontmatter changes its values")
+)
+
+// keyOrder ranks the frontmatter keys of skill.Skill in declaration order.
+var keyOrder = func() map[string]int {
+	order := make(map[string]int)
+	t := reflect.TypeFor[skill.Skill]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			order[name] = len(order)
+		}
+	}
+	return order
+}()
+
+// oldBools are the strings YAML 1.1, but not YAML 1.2, reads as booleans.
+var oldBools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true,
+	"on": true, "On": true, "ON": true,
+	"off": true, "Off": true, "OFF": true,
+}
+
+// Format returns the canonical form of the SKILL.md file data:
+//
+//   - line endings are LF;
+//   - frontmatter keys known to skills come first, in the order of the
+//     skill.Skill fields, followed by any others in their original order;
+//   - frontmatter values use block style, two-space indentation and quotes
+//     only where YAML needs them, keeping their comments;
+//   - one blank line separates the frontmatter from the instructions, and
+//     the file ends with a single newline.
+//
+// Instructions are otherwise left as written. Format returns
+// registry.ErrNoFrontmatter if data has no frontmatter.
+func Format(data []byte) ([]byte, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	if lines[0] != "---" {
+		return nil, registry.ErrNoFrontmatter
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, registry.ErrNoFrontmatter
+	}
+
+	// Each frontmatter line ends in a newline, as the registry reads it,
+	// so block scalars at the end keep their final line break.
+	var frontmatter strings.Builder
+	for _, line := range lines[1:end] {
+		frontmatter.WriteString(line)
+		frontmatter.WriteString("\n")
+	}
+	canonical, err := formatFrontmatter(frontmatter.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body := lines[end+1:]
+	for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+		body = body[1:]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(canonical)
+	buf.WriteString("---\n")
+	if rest := strings.TrimRight(strings.Join(body, "\n"), " \t\n"); rest != "" {
+		buf.WriteString("\n")
+		buf.WriteString(rest)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// formatFrontmatter returns the canonical form of the YAML frontmatter,
+// checking that it decodes to the same values.
+func formatFrontmatter(frontmatter string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		return nil, fmt.Errorf("parse frontmatter: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, ErrNotMapping
+	}
+	root := doc.Content[0]
+
+	sortKeys(root)
+	clearStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encode frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode frontmatter: %w", err)
+	}
+
+	var before, after any
+	if err := yaml.Unmarshal([]byte(frontmatter), &before); err != nil {
+		return nil, fmt.Errorf("parse frontmatter: %w", err)
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &after); err != nil || !reflect.DeepEqual(before, after) {
+		return nil, ErrUnstable
+	}
+	return buf.Bytes(), nil
+}
+
+// sortKeys orders the key-value pairs of the mapping node m by keyOrder,
+// keeping unknown keys after the known ones in their original order.
+func sortKeys(m *yaml.Node) {
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		pairs = append(pairs, pair{m.Content[i], m.Content[i+1]})
+	}
+	rank := func(p pair) int {
+		if r, ok := keyOrder[p.key.Value]; ok {
+			return r
+		}
+		return len(keyOrder)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return rank(pairs[i]) < rank(pairs[j])
+	})
+	for i, p := range pairs {
+		m.Content[2*i], m.Content[2*i+1] = p.key, p.value
+	}
+}
+
+// clearStyle resets the style of n and the nodes below it, so the encoder
+// picks its default: block collections, and plain scalars unless a value
+// needs quoting or spans lines. Strings that YAML 1.1 reads as booleans
+// stay quoted for tools that still follow it.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" && oldBools[n.Value] {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
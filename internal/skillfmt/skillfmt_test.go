@@ -0,0 +1,170 @@
+package skillfmt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "canonical",
+			in:   "---\nname: review\ndescription: Review code\n---\n\nCheck tests.\n",
+			want: "---\nname: review\ndescription: Review code\n---\n\nCheck tests.\n",
+		},
+		{
+			name: "key order and quoting",
+			in: `---
+tags: [go, "quality"]
+"description": 'Review code: carefully'
+owner: platform-team
+license: MIT
+name: review
+version: "1.0"
+metadata: {area: backend}
+---
+
+Check tests.
+`,
+			want: `---
+name: review
+description: 'Review code: carefully'
+version: "1.0"
+tags:
+  - go
+  - quality
+owner: platform-team
+license: MIT
+metadata:
+  area: backend
+---
+
+Check tests.
+`,
+		},
+		{
+			name: "comments and nested mappings",
+			in:   "---\n# The skill's name.\nname:    review   \ndescription: Review\nsections:\n    Security: append\n---\nBody.\n",
+			want: "---\n# The skill's name.\nname: review\ndescription: Review\nsections:\n  Security: append\n---\n\nBody.\n",
+		},
+		{
+			name: "line endings and blank lines",
+			in:   "---\r\nname: review\r\ndescription: Review\r\n---\r\n\r\n\r\n# Review\r\n\r\nLine one  \r\nline two\r\n\r\n\r\n",
+			want: "---\nname: review\ndescription: Review\n---\n\n# Review\n\nLine one  \nline two\n",
+		},
+		{
+			name: "indented first line kept",
+			in:   "---\nname: review\ndescription: Review\n---\n\n    code block\n",
+			want: "---\nname: review\ndescription: Review\n---\n\n    code block\n",
+		},
+		{
+			name: "no instructions",
+			in:   "---\nname: review\ndescription: Review\n---\n\n\n",
+			want: "---\nname: review\ndescription: Review\n---\n",
+		},
+		{
+			name: "strings that look like other types",
+			in:   "---\nname: review\ndescription: 'yes'\nversion: '2'\nowner: \"null\"\n---\n\nText.\n",
+			want: "---\nname: review\ndescription: \"yes\"\nversion: \"2\"\nowner: \"null\"\n---\n\nText.\n",
+		},
+		{
+			name: "multiline values",
+			in:   "---\nname: review\ndescription: >\n  Review code\n  carefully.\n---\n\nText.\n",
+			want: "---\nname: review\ndescription: |\n  Review code carefully.\n---\n\nText.\n",
+		},
+		{
+			name: "later delimiters are instructions",
+			in:   "---\nname: review\ndescription: Review\n---\n\nAbove\n\n---\n\nBelow\n",
+			want: "---\nname: review\ndescription: Review\n---\n\nAbove\n\n---\n\nBelow\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("Format() error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Format() =\n%s\nwant:\n%s", got, tt.want)
+			}
+			again, err := Format(got)
+			if err != nil {
+				t.Fatalf("Format() of canonical form error: %v", err)
+			}
+			if string(again) != string(got) {
+				t.Errorf("Format() is not idempotent:\n%s", again)
+			}
+
+			// The parsed skill is the same before and after.
+			before, err := registry.ParseSkillMDBytes([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("ParseSkillMDBytes() error: %v", err)
+			}
+			after, err := registry.ParseSkillMDBytes(got)
+			if err != nil {
+				t.Fatalf("ParseSkillMDBytes() of formatted error: %v", err)
+			}
+			if before.Name != after.Name || before.Description != after.Description || before.Version != after.Version ||
+				before.Owner != after.Owner || before.Instructions != after.Instructions {
+				t.Errorf("formatted skill = %+v, want %+v", after, before)
+			}
+		})
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"no frontmatter", "# Review\n", registry.ErrNoFrontmatter},
+		{"unterminated frontmatter", "---\nname: review\n", registry.ErrNoFrontmatter},
+		{"not a mapping", "---\n- name\n---\n\nText.\n", ErrNotMapping},
+		{"empty frontmatter", "---\n---\n\nText.\n", ErrNotMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Format([]byte(tt.in)); !errors.Is(err, tt.want) {
+				t.Errorf("Format() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Format([]byte("---\nname: [unclosed\n---\n")); err == nil {
+		t.Error("Format() of invalid YAML succeeded, want error")
+	}
+}
+
+func TestFormatSkillMDIsCanonical(t *testing.T) {
+	s := &skill.Skill{
+		Name:         "go-review",
+		Description:  "Review Go code: idioms & errors",
+		Extends:      "review",
+		Version:      "1.2.0",
+		Tags:         []string{"go", "quality"},
+		Owner:        "platform-team",
+		SectionModes: map[string]string{"Security": registry.SectionAppend},
+		Source:       "## Security\n\nCheck `os/exec` calls.",
+	}
+	data, err := registry.FormatSkillMD(s)
+	if err != nil {
+		t.Fatalf("FormatSkillMD() error: %v", err)
+	}
+	got, err := Format(data)
+	if err != nil {
+		t.Fatalf("Format() error: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Format() =\n%s\nwant FormatSkillMD() output:\n%s", got, data)
+	}
+}
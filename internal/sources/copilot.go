@@ -0,0 +1,51 @@
+package sources
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// copilotAdapter converts GitHub Copilot instructions: the repository-wide
+// .github/copilot-instructions.md and path-specific
+// .github/instructions/*.instructions.md files.
+type copilotAdapter struct{}
+
+const (
+	copilotRepoFile     = ".github/copilot-instructions.md"
+	copilotInstructions = ".github/instructions/"
+	copilotSuffix       = ".instructions.md"
+)
+
+func (copilotAdapter) Format() string { return "copilot" }
+
+func (copilotAdapter) Match(rel string) bool {
+	return rel == copilotRepoFile ||
+		strings.HasPrefix(rel, copilotInstructions) && strings.HasSuffix(rel, copilotSuffix)
+}
+
+func (copilotAdapter) Convert(root, rel, file string) (*skill.Skill, error) {
+	doc, err := readDocument(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := dirName(root, ".") + "-copilot-instructions"
+	if rel != copilotRepoFile {
+		fileName = strings.TrimSuffix(path.Base(rel), copilotSuffix)
+	}
+
+	name := slugName(firstNonEmpty(doc.str("name"), fileName))
+	if name == "" {
+		return nil, fmt.Errorf("no usable name for %s", rel)
+	}
+	description := firstNonEmpty(doc.str("description"), firstParagraph(doc.body), "Copilot instructions "+name)
+
+	return &skill.Skill{
+		Name:         name,
+		Description:  description,
+		Instructions: joinParagraphs(scopeNote(doc.str("applyTo")), doc.body),
+	}, nil
+}
@@ -0,0 +1,49 @@
+package sources
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// cursorAdapter converts Cursor rules: .mdc files under .cursor/rules and
+// the legacy .cursorrules file.
+type cursorAdapter struct{}
+
+func (cursorAdapter) Format() string { return "cursor" }
+
+func (cursorAdapter) Match(rel string) bool {
+	if path.Base(rel) == ".cursorrules" {
+		return true
+	}
+	return strings.HasSuffix(rel, ".mdc") &&
+		(strings.HasPrefix(rel, ".cursor/rules/") || strings.Contains(rel, "/.cursor/rules/"))
+}
+
+func (cursorAdapter) Convert(root, rel, file string) (*skill.Skill, error) {
+	doc, err := readDocument(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileName string
+	if path.Base(rel) == ".cursorrules" {
+		fileName = dirName(root, rel) + "-cursor-rules"
+	} else {
+		fileName = strings.TrimSuffix(path.Base(rel), ".mdc")
+	}
+
+	name := slugName(firstNonEmpty(doc.str("name"), fileName, firstHeading(doc.body)))
+	if name == "" {
+		return nil, fmt.Errorf("no usable name for %s", rel)
+	}
+	description := firstNonEmpty(doc.str("description"), firstParagraph(doc.body), "Cursor rule "+name)
+
+	return &skill.Skill{
+		Name:         name,
+		Description:  description,
+		Instructions: joinParagraphs(scopeNote(doc.str("globs")), doc.body),
+	}, nil
+}
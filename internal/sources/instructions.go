@@ -0,0 +1,41 @@
+package sources
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+)
+
+// instructionsAdapter converts project instruction files with a fixed name,
+// such as AGENTS.md and CLAUDE.md, found anywhere below the root.
+type instructionsAdapter struct {
+	format   string
+	fileName string
+}
+
+func (a instructionsAdapter) Format() string { return a.format }
+
+func (a instructionsAdapter) Match(rel string) bool {
+	return path.Base(rel) == a.fileName
+}
+
+func (a instructionsAdapter) Convert(root, rel, file string) (*skill.Skill, error) {
+	doc, err := readDocument(file)
+	if err != nil {
+		return nil, err
+	}
+
+	name := slugName(firstNonEmpty(doc.str("name"), firstHeading(doc.body), dirName(root, rel)+"-"+a.format))
+	if name == "" {
+		return nil, fmt.Errorf("no usable name for %s", rel)
+	}
+	description := firstNonEmpty(doc.str("description"), firstParagraph(doc.body),
+		fmt.Sprintf("Project instructions from %s", rel))
+
+	return &skill.Skill{
+		Name:         name,
+		Description:  description,
+		Instructions: doc.body,
+	}, nil
+}
@@ -0,0 +1,332 @@
+// Package sources imports skills from other agents' rule and instruction
+// formats, such as Cursor rules and AGENTS.md files.
+package sources
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/pkg/skill"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownFormat is returned when a source format name is not recognized.
+var ErrUnknownFormat = errors.New("unknown source format")
+
+// Adapter discovers and converts files in one source format.
+type Adapter interface {
+	// Format returns the adapter's name, e.g. "cursor".
+	Format() string
+
+	// Match reports whether the file at rel, a slash-separated path
+	// relative to the search root, is in the adapter's format.
+	Match(rel string) bool
+
+	// Convert maps the file at path, found at rel below the search root
+	// root, to a skill. The skill's Name, Description, Instructions and
+	// File are set.
+	Convert(root, rel, path string) (*skill.Skill, error)
+}
+
+// Adapters returns all source adapters.
+func Adapters() []Adapter {
+	return []Adapter{
+		cursorAdapter{},
+		instructionsAdapter{format: "agents", fileName: "AGENTS.md"},
+		instructionsAdapter{format: "claude", fileName: "CLAUDE.md"},
+		copilotAdapter{},
+	}
+}
+
+// Lookup returns the adapters for the named formats, or all adapters when
+// formats is empty.
+func Lookup(formats []string) ([]Adapter, error) {
+	all := Adapters()
+	if len(formats) == 0 {
+		return all, nil
+	}
+	var adapters []Adapter
+	for _, name := range formats {
+		found := false
+		for _, a := range all {
+			if a.Format() == name {
+				adapters = append(adapters, a)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+		}
+	}
+	return adapters, nil
+}
+
+// Formats returns the names of all source formats.
+func Formats() []string {
+	var names []string
+	for _, a := range Adapters() {
+		names = append(names, a.Format())
+	}
+	return names
+}
+
+// Imported is a skill converted from a source file.
+type Imported struct {
+	// Format is the name of the adapter that converted the file.
+	Format string
+
+	// Rel is the file's slash-separated path relative to the search root.
+	Rel string
+
+	// Skill is the converted skill.
+	Skill *skill.Skill
+
+	// Err is set, and Skill nil, when the file could not be converted.
+	Err error
+}
+
+// skipDirs are directories never searched for source files.
+var skipDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// Discover walks root for files matched by adapters and converts them,
+// returning the results in path order. A file matched by several adapters
+// is converted by the first. Hidden directories are searched, since
+// formats such as Cursor rules live in them.
+func Discover(root string, adapters []Adapter) ([]Imported, error) {
+	var found []Imported
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, a := range adapters {
+			if !a.Match(rel) {
+				continue
+			}
+			s, err := a.Convert(root, rel, path)
+			if err != nil {
+				found = append(found, Imported{Format: a.Format(), Rel: rel, Err: err})
+			} else {
+				s.File = path
+				found = append(found, Imported{Format: a.Format(), Rel: rel, Skill: s})
+			}
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk source root: %w", err)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Rel < found[j].Rel })
+	return found, nil
+}
+
+// document is a markdown file with optional YAML frontmatter.
+type document struct {
+	front map[string]any
+	body  string
+}
+
+// readDocument reads a markdown file, splitting off YAML frontmatter if
+// present. Files larger than registry.MaxSkillFileSize are rejected.
+func readDocument(path string) (document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return document{}, fmt.Errorf("stat source file: %w", err)
+	}
+	if info.Size() > registry.MaxSkillFileSize {
+		return document{}, fmt.Errorf("%w: %d bytes (max %d)", registry.ErrFileTooLarge, info.Size(), registry.MaxSkillFileSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return document{}, fmt.Errorf("read source file: %w", err)
+	}
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	text := string(data)
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return document{body: strings.TrimSpace(text)}, nil
+	}
+	front, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		front, ok = strings.CutSuffix(strings.TrimRight(rest, "\n"), "\n---")
+		body = ""
+		if !ok {
+			return document{body: strings.TrimSpace(text)}, nil
+		}
+	}
+
+	doc := document{body: strings.TrimSpace(body)}
+	if err := yaml.Unmarshal([]byte(front), &doc.front); err != nil {
+		return document{}, fmt.Errorf("parse frontmatter: %w", err)
+	}
+	return doc, nil
+}
+
+// str returns the frontmatter value for key as a string. Lists are joined
+// with commas.
+func (d document) str(key string) string {
+	switch v := d.front[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []any:
+		var parts []string
+		for _, item := range v {
+			parts = append(parts, strings.TrimSpace(fmt.Sprint(item)))
+		}
+		return strings.Join(parts, ", ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// headingPattern matches an ATX heading line.
+var headingPattern = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+
+// firstHeading returns the text of the first heading in body.
+func firstHeading(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// maxDescriptionLength bounds descriptions taken from a file's first paragraph.
+const maxDescriptionLength = 200
+
+// firstParagraph returns the first paragraph of prose in body, outside
+// headings, lists and code blocks, with whitespace collapsed and truncated
+// at a word boundary to maxDescriptionLength.
+func firstParagraph(body string) string {
+	var (
+		para  []string
+		fence bool
+	)
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = !fence
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		if fence {
+			continue
+		}
+		prose := trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "<!--") &&
+			!strings.HasPrefix(trimmed, "- ") && !strings.HasPrefix(trimmed, "* ") && !strings.HasPrefix(trimmed, "|")
+		if !prose {
+			if len(para) > 0 {
+				break
+			}
+			continue
+		}
+		para = append(para, trimmed)
+	}
+
+	text := strings.Join(para, " ")
+	if len(text) <= maxDescriptionLength {
+		return text
+	}
+	cut := strings.LastIndex(text[:maxDescriptionLength], " ")
+	if cut <= 0 {
+		cut = maxDescriptionLength
+	}
+	return strings.TrimRight(text[:cut], " ,;:") + "..."
+}
+
+// slugName converts text to a lowercase, hyphen-separated skill name.
+func slugName(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+// dirName returns a name for the directory containing rel, using root's
+// own name for files at the top level.
+func dirName(root, rel string) string {
+	dir := filepath.Dir(filepath.FromSlash(rel))
+	if dir == "." {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return ""
+		}
+		return filepath.Base(abs)
+	}
+	return strings.ReplaceAll(filepath.ToSlash(dir), "/", "-")
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// scopeNote returns a line recording the file patterns a rule applied to in
+// its original format, or "" when it had none.
+func scopeNote(patterns string) string {
+	if patterns == "" {
+		return ""
+	}
+	return "_Applies to files matching: " + patterns + "_"
+}
+
+// joinParagraphs joins non-empty markdown blocks with blank lines.
+func joinParagraphs(blocks ...string) string {
+	var out []string
+	for _, b := range blocks {
+		if b = strings.TrimSpace(b); b != "" {
+			out = append(out, b)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
@@ -0,0 +1,156 @@
+package sources
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "webapp")
+
+	writeFile(t, filepath.Join(root, ".cursor", "rules", "react-style.mdc"), `---
+description: React component conventions
+globs: src/**/*.tsx
+alwaysApply: false
+---
+
+Use function components.
+`)
+	writeFile(t, filepath.Join(root, ".cursorrules"), "Prefer small commits.\n")
+	writeFile(t, filepath.Join(root, "AGENTS.md"), "# Build and Test\n\nRun make before pushing changes.\n\n## Style\n\nUse tabs.\n")
+	writeFile(t, filepath.Join(root, "api", "CLAUDE.md"), "- Keep handlers thin.\n")
+	writeFile(t, filepath.Join(root, ".github", "copilot-instructions.md"), "Write tests for every change.\n")
+	writeFile(t, filepath.Join(root, ".github", "instructions", "go.instructions.md"), "---\napplyTo: \"**/*.go\"\n---\n\nWrap errors with context.\n")
+	writeFile(t, filepath.Join(root, "node_modules", "dep", "AGENTS.md"), "# Ignored\n")
+	writeFile(t, filepath.Join(root, "README.md"), "# Not a rule\n")
+
+	found, err := Discover(root, Adapters())
+	if err != nil {
+		t.Fatalf("Discover() error: %v", err)
+	}
+
+	type result struct {
+		format, name, description, instructions string
+	}
+	want := map[string]result{
+		".cursor/rules/react-style.mdc": {"cursor", "react-style", "React component conventions",
+			"_Applies to files matching: src/**/*.tsx_\n\nUse function components."},
+		".cursorrules": {"cursor", "webapp-cursor-rules", "Prefer small commits.", "Prefer small commits."},
+		".github/copilot-instructions.md": {"copilot", "webapp-copilot-instructions", "Write tests for every change.",
+			"Write tests for every change."},
+		".github/instructions/go.instructions.md": {"copilot", "go", "Wrap errors with context.",
+			"_Applies to files matching: **/*.go_\n\nWrap errors with context."},
+		"AGENTS.md": {"agents", "build-and-test", "Run make before pushing changes.",
+			"# Build and Test\n\nRun make before pushing changes.\n\n## Style\n\nUse tabs."},
+		"api/CLAUDE.md": {"claude", "api-claude", "Project instructions from api/CLAUDE.md", "- Keep handlers thin."},
+	}
+
+	if len(found) != len(want) {
+		var rels []string
+		for _, imp := range found {
+			rels = append(rels, imp.Rel)
+		}
+		t.Fatalf("Discover() found %v, want %d files", rels, len(want))
+	}
+	for _, imp := range found {
+		w, ok := want[imp.Rel]
+		if !ok {
+			t.Errorf("unexpected file %s", imp.Rel)
+			continue
+		}
+		if imp.Err != nil {
+			t.Errorf("%s: error %v", imp.Rel, imp.Err)
+			continue
+		}
+		got := result{imp.Format, imp.Skill.Name, imp.Skill.Description, imp.Skill.Instructions}
+		if got != w {
+			t.Errorf("%s = %+v, want %+v", imp.Rel, got, w)
+		}
+		if imp.Skill.File != filepath.Join(root, filepath.FromSlash(imp.Rel)) {
+			t.Errorf("%s File = %s", imp.Rel, imp.Skill.File)
+		}
+	}
+}
+
+func TestDiscoverFormats(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "AGENTS.md"), "# Agents\n\nText.\n")
+	writeFile(t, filepath.Join(root, "CLAUDE.md"), "# Claude\n\nText.\n")
+
+	adapters, err := Lookup([]string{"claude"})
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	found, err := Discover(root, adapters)
+	if err != nil {
+		t.Fatalf("Discover() error: %v", err)
+	}
+	if len(found) != 1 || found[0].Rel != "CLAUDE.md" {
+		t.Errorf("Discover() = %+v, want only CLAUDE.md", found)
+	}
+
+	if _, err := Lookup([]string{"windsurf"}); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Lookup(windsurf) error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestDiscoverInvalidFrontmatter(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".cursor", "rules", "bad.mdc"), "---\ndescription: [unclosed\n---\n\nText.\n")
+
+	found, err := Discover(root, Adapters())
+	if err != nil {
+		t.Fatalf("Discover() error: %v", err)
+	}
+	if len(found) != 1 || found[0].Err == nil || found[0].Skill != nil {
+		t.Errorf("Discover() = %+v, want one conversion error", found)
+	}
+}
+
+func TestFirstParagraph(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"after heading", "# Title\n\nFirst line\nwraps here.\n\nSecond.", "First line wraps here."},
+		{"skips code", "```\ncode\n```\n\nProse.", "Prose."},
+		{"list only", "- one\n- two", ""},
+		{"truncated", strings.Repeat("word ", 60), strings.TrimSpace(strings.Repeat("word ", 40)) + "..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstParagraph(tt.body); got != tt.want {
+				t.Errorf("firstParagraph() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlugName(t *testing.T) {
+	tests := map[string]string{
+		"Build and Test":  "build-and-test",
+		"react_style.v2":  "react-style-v2",
+		"  --Leading--  ": "leading",
+		"日本語":             "",
+	}
+	for in, want := range tests {
+		if got := slugName(in); got != want {
+			t.Errorf("slugName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
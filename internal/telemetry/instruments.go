@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope used for all spans and metrics.
+const ScopeName = "github.com/portertech/skills-mcp-server"
+
+// Attribute keys shared by spans and metrics.
+const (
+	AttrSkillName = attribute.Key("skill.name")
+	AttrToolName  = attribute.Key("skill.tool_name")
+	AttrSkillPath = attribute.Key("skill.path")
+	AttrRoot      = attribute.Key("skills.root")
+)
+
+// Instruments are created against the global providers, which delegate to
+// whatever Setup installs, so they are safe to create at package init.
+var (
+	meter = otel.Meter(ScopeName)
+
+	skillCount, _ = meter.Int64Gauge("skills.count",
+		metric.WithDescription("Number of skills currently registered"),
+		metric.WithUnit("{skill}"),
+	)
+	scanDuration, _ = meter.Float64Histogram("skills.scan.duration",
+		metric.WithDescription("Duration of skill registry scans"),
+		metric.WithUnit("s"),
+	)
+	parseFailures, _ = meter.Int64Counter("skills.parse.failures",
+		metric.WithDescription("Number of SKILL.md files that failed to parse"),
+		metric.WithUnit("{file}"),
+	)
+	invocations, _ = meter.Int64Counter("skills.invocations",
+		metric.WithDescription("Number of skill tool invocations"),
+		metric.WithUnit("{call}"),
+	)
+	responseSize, _ = meter.Int64Histogram("skills.response.size",
+		metric.WithDescription("Size of skill tool responses"),
+		metric.WithUnit("By"),
+	)
+)
+
+// Tracer returns the tracer used for skill instrumentation.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ScopeName)
+}
+
+// RecordScan records the outcome of a registry scan.
+func RecordScan(ctx context.Context, root string, d time.Duration, skills int) {
+	attrs := metric.WithAttributes(AttrRoot.String(root))
+	scanDuration.Record(ctx, d.Seconds(), attrs)
+	skillCount.Record(ctx, int64(skills), attrs)
+}
+
+// RecordParseFailure records a SKILL.md file that could not be parsed.
+func RecordParseFailure(ctx context.Context) {
+	parseFailures.Add(ctx, 1)
+}
+
+// RecordInvocation records a skill tool call and the size of its response.
+func RecordInvocation(ctx context.Context, skillName string, responseBytes int) {
+	attrs := metric.WithAttributes(AttrSkillName.String(skillName))
+	invocations.Add(ctx, 1, attrs)
+	responseSize.Record(ctx, int64(responseBytes), attrs)
+}
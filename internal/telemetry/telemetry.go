@@ -0,0 +1,137 @@
+// Package telemetry configures OpenTelemetry tracing and metrics.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Supported exporter names.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// ErrUnknownExporter is returned when Config.Exporter is not a supported exporter.
+var ErrUnknownExporter = errors.New("unknown telemetry exporter")
+
+// Config controls how telemetry is exported.
+type Config struct {
+	// Exporter selects the exporter: "none", "stdout" or "otlp".
+	Exporter string
+
+	// ServiceName and ServiceVersion identify this process in exported data.
+	ServiceName    string
+	ServiceVersion string
+
+	// Writer receives stdout exporter output. Defaults to os.Stderr, since
+	// stdout carries the MCP stdio transport.
+	Writer io.Writer
+
+	// Endpoint overrides the OTLP/HTTP endpoint (host:port). When empty the
+	// standard OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint string
+
+	// Insecure disables TLS for the OTLP exporter.
+	Insecure bool
+}
+
+// ShutdownFunc flushes and stops the configured providers.
+type ShutdownFunc func(context.Context) error
+
+// Setup installs global tracer and meter providers according to cfg.
+// With the "none" exporter it installs nothing and instrumentation stays a no-op.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	if cfg.Exporter == "" || cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "skills-mcp-server"
+	}
+	if cfg.Writer == nil {
+		cfg.Writer = os.Stderr
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build resource: %w", err)
+	}
+
+	var (
+		spanExporter   sdktrace.SpanExporter
+		metricExporter sdkmetric.Exporter
+	)
+	switch cfg.Exporter {
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(cfg.Writer))
+		if err != nil {
+			return nil, fmt.Errorf("create stdout span exporter: %w", err)
+		}
+		metricExporter, err = stdoutmetric.New(stdoutmetric.WithWriter(cfg.Writer))
+		if err != nil {
+			return nil, fmt.Errorf("create stdout metric exporter: %w", err)
+		}
+	case ExporterOTLP:
+		var (
+			traceOpts  []otlptracehttp.Option
+			metricOpts []otlpmetrichttp.Option
+		)
+		if cfg.Endpoint != "" {
+			traceOpts = append(traceOpts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+			metricOpts = append(metricOpts, otlpmetrichttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+			metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		}
+		spanExporter, err = otlptracehttp.New(ctx, traceOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create OTLP span exporter: %w", err)
+		}
+		metricExporter, err = otlpmetrichttp.New(ctx, metricOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create OTLP metric exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(spanExporter),
+	)
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx))
+	}, nil
+}
@@ -0,0 +1,185 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/portertech/skills-mcp-server/internal/registry"
+	"github.com/portertech/skills-mcp-server/internal/server"
+	"github.com/portertech/skills-mcp-server/internal/telemetry"
+	metricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a minimal in-process OTLP/HTTP receiver.
+type collector struct {
+	mu      sync.Mutex
+	spans   []string
+	metrics map[string]bool
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/v1/traces":
+		var req tracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					c.spans = append(c.spans, span.Name)
+				}
+			}
+		}
+		resp, _ := proto.Marshal(&tracepb.ExportTraceServiceResponse{})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Write(resp)
+	case "/v1/metrics":
+		var req metricspb.ExportMetricsServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rm := range req.ResourceMetrics {
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					c.metrics[m.Name] = true
+				}
+			}
+		}
+		resp, _ := proto.Marshal(&metricspb.ExportMetricsServiceResponse{})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Write(resp)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSetupOTLP(t *testing.T) {
+	col := &collector{metrics: make(map[string]bool)}
+	ts := httptest.NewServer(col)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	shutdown, err := telemetry.Setup(ctx, telemetry.Config{
+		Exporter: telemetry.ExporterOTLP,
+		Endpoint: strings.TrimPrefix(ts.URL, "http://"),
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup() error: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "greet")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatalf("failed to create skill dir: %v", err)
+	}
+	content := "---\nname: greet\ndescription: Greeting instructions\n---\n\nSay hello.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write skill: %v", err)
+	}
+	brokenDir := filepath.Join(tmpDir, "broken")
+	if err := os.MkdirAll(brokenDir, 0755); err != nil {
+		t.Fatalf("failed to create broken dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(brokenDir, "SKILL.md"), []byte("no frontmatter\n"), 0644); err != nil {
+		t.Fatalf("failed to write broken skill: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	reg := registry.NewRegistry(tmpDir, logger)
+	if err := reg.Scan(); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	srv := server.New(reg, logger)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	go func() {
+		srv.RunWithTransport(ctx, serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Connect() error: %v", err)
+	}
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "greet"}); err != nil {
+		t.Fatalf("CallTool() error: %v", err)
+	}
+	session.Close()
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown error: %v", err)
+	}
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	for _, want := range []string{"registry.Scan", "registry.ParseSkillMD", "skills.call greet"} {
+		found := false
+		for _, name := range col.spans {
+			if name == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("collector missing span %q (got %v)", want, col.spans)
+		}
+	}
+
+	for _, want := range []string{
+		"skills.count",
+		"skills.scan.duration",
+		"skills.parse.failures",
+		"skills.invocations",
+		"skills.response.size",
+	} {
+		if !col.metrics[want] {
+			t.Errorf("collector missing metric %q", want)
+		}
+	}
+}
+
+func TestSetupNone(t *testing.T) {
+	shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: telemetry.ExporterNone})
+	if err != nil {
+		t.Fatalf("Setup() error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown error: %v", err)
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	_, err := telemetry.Setup(context.Background(), telemetry.Config{Exporter: "carrier-pigeon"})
+	if !errors.Is(err, telemetry.ErrUnknownExporter) {
+		t.Errorf("expected ErrUnknownExporter, got %v", err)
+	}
+}
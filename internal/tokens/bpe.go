@@ -0,0 +1,116 @@
+package tokens
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVocab is returned when a BPE vocabulary file cannot be parsed.
+var ErrInvalidVocab = errors.New("invalid BPE vocabulary")
+
+// pretokenize approximates the splitting regex used by tiktoken encodings:
+// contractions, letter runs, digit runs (up to three), punctuation runs and
+// whitespace, each optionally led by a single space.
+var pretokenize = regexp.MustCompile(`'(?:[sdmt]|ll|ve|re)| ?\pL+| ?\pN{1,3}| ?[^\s\pL\pN]+|\s+`)
+
+// BPE counts tokens by running byte-pair encoding against a vocabulary in
+// the tiktoken format: one base64-encoded token and its merge rank per line.
+type BPE struct {
+	name  string
+	ranks map[string]int
+}
+
+// LoadBPE reads a tiktoken-format vocabulary file.
+func LoadBPE(path string) (*BPE, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open vocabulary: %w", err)
+	}
+	defer f.Close()
+
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: expected token and rank", ErrInvalidVocab, lineNum)
+		}
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidVocab, lineNum, err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidVocab, lineNum, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read vocabulary: %w", err)
+	}
+	if len(ranks) == 0 {
+		return nil, fmt.Errorf("%w: no tokens", ErrInvalidVocab)
+	}
+
+	return &BPE{
+		name:  "bpe:" + strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		ranks: ranks,
+	}, nil
+}
+
+// Count implements Estimator.
+func (b *BPE) Count(text string) int {
+	n := 0
+	for _, piece := range pretokenize.FindAllString(text, -1) {
+		if _, ok := b.ranks[piece]; ok {
+			n++
+			continue
+		}
+		n += b.countPiece(piece)
+	}
+	return n
+}
+
+// Name implements Estimator.
+func (b *BPE) Name() string {
+	return b.name
+}
+
+// countPiece byte-pair encodes a single pre-tokenized piece, repeatedly
+// merging the adjacent pair with the lowest rank until no merge applies.
+func (b *BPE) countPiece(piece string) int {
+	parts := make([]string, len(piece))
+	for i := 0; i < len(piece); i++ {
+		parts[i] = piece[i : i+1]
+	}
+
+	for len(parts) > 1 {
+		best, bestRank := -1, 0
+		for i := 0; i < len(parts)-1; i++ {
+			rank, ok := b.ranks[parts[i]+parts[i+1]]
+			if ok && (best < 0 || rank < bestRank) {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		parts[best] += parts[best+1]
+		parts = append(parts[:best+1], parts[best+2:]...)
+	}
+
+	return len(parts)
+}
@@ -0,0 +1,41 @@
+// Package tokens estimates how many model tokens a piece of text consumes.
+package tokens
+
+import (
+	"unicode/utf8"
+)
+
+// Estimator counts the tokens in a piece of text.
+type Estimator interface {
+	// Count returns the estimated number of tokens in text.
+	Count(text string) int
+
+	// Name identifies the estimator in logs and listings.
+	Name() string
+}
+
+// Heuristic estimates tokens without a vocabulary. ASCII text averages
+// roughly four bytes per token for common BPE vocabularies, while non-ASCII
+// characters (CJK in particular) tend to be one token or more each.
+type Heuristic struct{}
+
+// Count implements Estimator.
+func (Heuristic) Count(text string) int {
+	var ascii, other int
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+	return (ascii+3)/4 + other
+}
+
+// Name implements Estimator.
+func (Heuristic) Name() string {
+	return "heuristic"
+}
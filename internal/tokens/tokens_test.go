@@ -0,0 +1,89 @@
+package tokens
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHeuristic(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"日本語", 3},
+		{"hi 日本", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Heuristic{}.Count(tt.input)
+			if got != tt.want {
+				t.Errorf("Count(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func writeVocab(t *testing.T, tokens []string) string {
+	t.Helper()
+	var sb strings.Builder
+	for i, tok := range tokens {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(tok)), i)
+	}
+	path := filepath.Join(t.TempDir(), "test.tiktoken")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("failed to write vocabulary: %v", err)
+	}
+	return path
+}
+
+func TestBPE(t *testing.T) {
+	// Single bytes first, then merges in rank order.
+	vocab := []string{"h", "e", "l", "o", " ", "w", "r", "d", "he", "ll", "hell", "hello", " w", "or", " wor"}
+	bpe, err := LoadBPE(writeVocab(t, vocab))
+	if err != nil {
+		t.Fatalf("LoadBPE() error: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"hello", 1},
+		{"hello world", 4}, // "hello", " wor", "l", "d"
+		{"help", 3},        // "hel" has no merge: "he", "l", "p"
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := bpe.Count(tt.input)
+			if got != tt.want {
+				t.Errorf("Count(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if bpe.Name() != "bpe:test" {
+		t.Errorf("Name() = %q, want %q", bpe.Name(), "bpe:test")
+	}
+}
+
+func TestLoadBPEInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.tiktoken")
+	if err := os.WriteFile(path, []byte("not-base64!! x\n"), 0644); err != nil {
+		t.Fatalf("failed to write vocabulary: %v", err)
+	}
+
+	_, err := LoadBPE(path)
+	if !errors.Is(err, ErrInvalidVocab) {
+		t.Errorf("expected ErrInvalidVocab, got %v", err)
+	}
+}
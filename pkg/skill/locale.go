@@ -0,0 +1,40 @@
+package skill
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeLocale returns a language tag in the form used to key Variants:
+// lowercase, with hyphens as separators, e.g. "pt_BR" becomes "pt-br".
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// Localize returns the variant best matching locale, falling back from a
+// regional tag to its base language ("ja-JP" to "ja") and then to the
+// default skill. An empty locale selects the default skill.
+func (s *Skill) Localize(locale string) *Skill {
+	locale = NormalizeLocale(locale)
+	for locale != "" {
+		if v, ok := s.Variants[locale]; ok {
+			return v
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return s
+}
+
+// Locales returns the language tags of the skill's variants, sorted.
+func (s *Skill) Locales() []string {
+	locales := make([]string, 0, len(s.Variants))
+	for locale := range s.Variants {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
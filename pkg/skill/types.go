@@ -1,17 +1,152 @@
 // Package skill defines the core types for Claude-compatible skills.
 package skill
 
+import "strings"
+
 // Skill represents a Claude-compatible skill parsed from a SKILL.md file.
 type Skill struct {
 	// Name is the unique identifier for the skill (required).
 	Name string `yaml:"name"`
 
-	// Description explains what the skill does (required).
+	// Description explains what the skill does (required unless Extends is
+	// set, in which case it is inherited from the parent).
 	Description string `yaml:"description"`
 
-	// Instructions contains the markdown content after the YAML frontmatter.
+	// Extends names a parent skill whose description and instructions this
+	// skill inherits.
+	Extends string `yaml:"extends,omitempty"`
+
+	// ToolName overrides the MCP tool name derived from Name. It is used
+	// verbatim and must be a valid MCP tool name.
+	ToolName string `yaml:"tool_name,omitempty"`
+
+	// Version is the skill's version, e.g. "1.2.0", used to pick between
+	// skills with the same name under the highest-version duplicate policy.
+	Version string `yaml:"version,omitempty"`
+
+	// Priority ranks skills with the same name under the explicit-priority
+	// duplicate policy; the highest wins.
+	Priority int `yaml:"priority,omitempty"`
+
+	// Tags are free-form labels, e.g. "testing" or "go", for finding and
+	// grouping related skills. They do not affect how a skill is served.
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Owner names the person or team responsible for the skill, e.g. an
+	// email address or a team handle.
+	Owner string `yaml:"owner,omitempty"`
+
+	// SectionModes maps parent section headings (title or slug) to how this
+	// skill's section of the same heading is merged: "replace" (the default),
+	// "append" or "prepend".
+	SectionModes map[string]string `yaml:"sections,omitempty"`
+
+	// Instructions contains the markdown content after the YAML frontmatter,
+	// with include directives resolved.
 	Instructions string `yaml:"-"`
 
-	// Path is the filesystem path to the skill directory.
+	// Source contains the markdown content as written, before directives
+	// are resolved.
+	Source string `yaml:"-"`
+
+	// Namespace is the dot-separated path, relative to the skills root, of
+	// the directory containing the skill's directory, e.g. "backend" for
+	// backend/testing/SKILL.md. It is empty unless namespacing is enabled.
+	Namespace string `yaml:"-"`
+
+	// Tool is the MCP tool name the skill is registered under.
+	Tool string `yaml:"-"`
+
+	// Path is the filesystem path to the skill directory, or for a
+	// single-file skill, the directory containing the file.
 	Path string `yaml:"-"`
+
+	// File is the filesystem path to the skill's markdown file.
+	File string `yaml:"-"`
+
+	// Hash is the hex-encoded SHA-256 digest of the skill's markdown file
+	// as read, identifying its exact content. Included files do not
+	// contribute to it.
+	Hash string `yaml:"-"`
+
+	// Size is the size in bytes of the skill's markdown file.
+	Size int64 `yaml:"-"`
+
+	// Includes lists every file whose content was pulled into Instructions,
+	// directly or transitively, so changes to any of them can be detected.
+	Includes []string `yaml:"-"`
+
+	// Lineage is the resolved extends chain, starting with this skill.
+	Lineage []string `yaml:"-"`
+
+	// Tokens is the estimated number of model tokens in Instructions.
+	Tokens int `yaml:"-"`
+
+	// Sections is the table of contents of Instructions, in document order.
+	Sections []Section `yaml:"-"`
+
+	// Locale is the normalized language tag of a localized variant, read
+	// from its SKILL.<lang>.md file name. It is empty for the default skill.
+	Locale string `yaml:"-"`
+
+	// Variants maps normalized language tags to localized versions of the
+	// skill. Only the default skill has variants.
+	Variants map[string]*Skill `yaml:"-"`
+}
+
+// QualifiedName returns the skill's name prefixed with its namespace, e.g.
+// "backend.testing", or just its name when it has no namespace. It uniquely
+// identifies the skill within a registry.
+func (s *Skill) QualifiedName() string {
+	if s.Namespace == "" {
+		return s.Name
+	}
+	return s.Namespace + "." + s.Name
+}
+
+// Section is a markdown heading within a skill's instructions.
+type Section struct {
+	// Title is the heading text.
+	Title string
+
+	// Slug is a unique, URL-style identifier derived from Title.
+	Slug string
+
+	// Level is the heading level, 1 through 6.
+	Level int
+
+	// Start and End are byte offsets into Instructions. The section begins at
+	// its heading line and ends before the next heading of the same or a
+	// higher level, so it includes its subsections.
+	Start int
+	End   int
+}
+
+// Content returns the section's markdown, including its heading.
+func (s Section) Content(instructions string) string {
+	return strings.TrimSpace(instructions[s.Start:s.End])
+}
+
+// Overview returns the instructions preceding the first section, skipping a
+// leading top-level title heading. It is the whole document when there are
+// no further sections.
+func (s *Skill) Overview() string {
+	for _, sec := range s.Sections {
+		if sec.Start == 0 && sec.Level == 1 {
+			continue
+		}
+		return strings.TrimSpace(s.Instructions[:sec.Start])
+	}
+	return s.Instructions
+}
+
+// Section returns the section whose slug or title matches name,
+// case-insensitively.
+func (s *Skill) Section(name string) (Section, bool) {
+	for _, sec := range s.Sections {
+		if strings.EqualFold(sec.Slug, name) || strings.EqualFold(sec.Title, name) {
+			return sec, true
+		}
+	}
+	return Section{}, false
 }